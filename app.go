@@ -2,9 +2,13 @@ package main
 
 import (
 	"context"
+	"database/sql"
 	"errors"
 	"fd-call-log/internal/db"
+	"fd-call-log/internal/export"
 	"fmt"
+	"strings"
+	"time"
 )
 
 var ErrUnauthorized = errors.New("unauthorized")
@@ -194,33 +198,362 @@ func (a *App) DeletePicklist(id int) error {
 	return a.db.DeletePicklistItem(id)
 }
 
+// GetApparatus returns every unit, including retired ones
+func (a *App) GetApparatus() ([]db.Apparatus, error) {
+	return a.db.GetAllApparatus()
+}
+
+// GetActiveApparatus returns the units that can be assigned to calls
+func (a *App) GetActiveApparatus() ([]db.Apparatus, error) {
+	return a.db.GetActiveApparatus()
+}
+
+// CreateApparatus adds a unit (admin only)
+func (a *App) CreateApparatus(unit *db.Apparatus) error {
+	if a.currentUser == nil || !a.currentUser.IsAdmin {
+		return ErrUnauthorized
+	}
+	return a.db.CreateApparatus(unit)
+}
+
+// UpdateApparatus updates a unit's attributes and status (admin only)
+func (a *App) UpdateApparatus(unit *db.Apparatus) error {
+	if a.currentUser == nil || !a.currentUser.IsAdmin {
+		return ErrUnauthorized
+	}
+	return a.db.UpdateApparatus(unit)
+}
+
+// SetApparatusInService puts a unit in or out of service
+func (a *App) SetApparatusInService(id int, inService bool) error {
+	if a.currentUser == nil {
+		return ErrUnauthorized
+	}
+	return a.db.SetApparatusInService(id, inService)
+}
+
+// DeleteApparatus retires a unit (admin only)
+func (a *App) DeleteApparatus(id int) error {
+	if a.currentUser == nil || !a.currentUser.IsAdmin {
+		return ErrUnauthorized
+	}
+	return a.db.DeleteApparatus(id)
+}
+
+// SaveApparatusUsage records a unit's mileage and hour readings for a call
+func (a *App) SaveApparatusUsage(usage *db.ApparatusUsage) error {
+	if a.currentUser == nil {
+		return ErrUnauthorized
+	}
+	usage.RecordedBy = a.currentUser.ID
+	return a.db.SaveApparatusUsage(usage)
+}
+
+// GetCallApparatusUsage returns the unit readings recorded for a call
+func (a *App) GetCallApparatusUsage(callID int) ([]db.ApparatusUsage, error) {
+	return a.db.GetCallApparatusUsage(callID)
+}
+
+// GetApparatusUsageHistory returns a page of a unit's readings, most recent first
+func (a *App) GetApparatusUsageHistory(apparatusID int, cursor string, limit int) (*db.ApparatusUsagePage, error) {
+	return a.db.GetApparatusUsageHistory(apparatusID, cursor, limit)
+}
+
+// RecordApparatusService records maintenance performed on a unit (admin only)
+func (a *App) RecordApparatusService(service *db.ApparatusService) error {
+	if a.currentUser == nil || !a.currentUser.IsAdmin {
+		return ErrUnauthorized
+	}
+	service.RecordedBy = a.currentUser.ID
+	return a.db.RecordApparatusService(service)
+}
+
+// GetMaintenanceDueReport lists units past their maintenance thresholds
+func (a *App) GetMaintenanceDueReport() ([]db.MaintenanceDue, error) {
+	return a.db.GetMaintenanceDueReport()
+}
+
+// GetInventoryItems returns the active inventory catalog
+func (a *App) GetInventoryItems() ([]db.InventoryItem, error) {
+	return a.db.GetInventoryItems()
+}
+
+// GetAllInventoryItems returns every catalog item, including inactive ones
+func (a *App) GetAllInventoryItems() ([]db.InventoryItem, error) {
+	if a.currentUser == nil || !a.currentUser.IsAdmin {
+		return nil, ErrUnauthorized
+	}
+	return a.db.GetAllInventoryItems()
+}
+
+// CreateInventoryItem adds an item to the catalog (admin only)
+func (a *App) CreateInventoryItem(item *db.InventoryItem) error {
+	if a.currentUser == nil || !a.currentUser.IsAdmin {
+		return ErrUnauthorized
+	}
+	return a.db.CreateInventoryItem(item)
+}
+
+// UpdateInventoryItem updates a catalog item (admin only)
+func (a *App) UpdateInventoryItem(item *db.InventoryItem) error {
+	if a.currentUser == nil || !a.currentUser.IsAdmin {
+		return ErrUnauthorized
+	}
+	return a.db.UpdateInventoryItem(item)
+}
+
+// DeleteInventoryItem deactivates a catalog item (admin only)
+func (a *App) DeleteInventoryItem(id int) error {
+	if a.currentUser == nil || !a.currentUser.IsAdmin {
+		return ErrUnauthorized
+	}
+	return a.db.DeleteInventoryItem(id)
+}
+
+// GetCallInventoryUsage returns the supplies used on a call
+func (a *App) GetCallInventoryUsage(callID int) ([]db.InventoryUsage, error) {
+	return a.db.GetCallInventoryUsage(callID)
+}
+
+// AddCallInventoryUsage records supplies used on a call
+func (a *App) AddCallInventoryUsage(usage *db.InventoryUsage) error {
+	if a.currentUser == nil {
+		return ErrUnauthorized
+	}
+	return a.db.AddCallInventoryUsage(usage)
+}
+
+// UpdateCallInventoryUsage changes a usage line's quantity or notes
+func (a *App) UpdateCallInventoryUsage(usage *db.InventoryUsage) error {
+	if a.currentUser == nil {
+		return ErrUnauthorized
+	}
+	return a.db.UpdateCallInventoryUsage(usage)
+}
+
+// DeleteCallInventoryUsage removes a usage line from a call
+func (a *App) DeleteCallInventoryUsage(id int) error {
+	if a.currentUser == nil {
+		return ErrUnauthorized
+	}
+	return a.db.DeleteCallInventoryUsage(id)
+}
+
+// GetInventoryUsageReport totals supplies used on calls between two dates (YYYY-MM-DD)
+func (a *App) GetInventoryUsageReport(startDate, endDate string) ([]db.InventoryUsageTotal, error) {
+	start, end, err := parseDateRange(startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+	return a.db.GetInventoryUsageReport(start, end)
+}
+
+// ExportCallPDF writes a single call report with its fire details, custom
+// fields, supplies, parties and image attachments to a PDF file. Party PII
+// is only included for admins.
+func (a *App) ExportCallPDF(callID int, filename string) error {
+	if a.currentUser == nil {
+		return ErrUnauthorized
+	}
+	call, apparatus, responders, err := a.db.GetCallByID(callID)
+	if err == sql.ErrNoRows {
+		return errors.New("call not found")
+	}
+	if err != nil {
+		return err
+	}
+
+	sections := export.CallPDFSections{IncludePII: a.currentUser.IsAdmin}
+	if sections.FireDetails, err = a.db.GetFireDetails(callID); err != nil {
+		return err
+	}
+	if sections.CustomFields, err = a.db.GetCustomFields(); err != nil {
+		return err
+	}
+	if sections.InventoryUsage, err = a.db.GetCallInventoryUsage(callID); err != nil {
+		return err
+	}
+	if sections.Parties, err = a.db.GetPartiesByCallID(callID); err != nil {
+		return err
+	}
+
+	attachments, err := a.db.GetAttachmentsByCallID(callID)
+	if err != nil {
+		return err
+	}
+	for _, attachment := range attachments {
+		if !strings.HasPrefix(attachment.MimeType, "image/") {
+			continue
+		}
+		image, err := a.db.GetAttachment(attachment.ID)
+		if err != nil {
+			return err
+		}
+		if image != nil {
+			sections.Images = append(sections.Images, *image)
+		}
+	}
+	return export.GenerateCallPDF(call, apparatus, responders, sections, filename)
+}
+
+// parseDateRange parses YYYY-MM-DD dates into a local time range that
+// covers the whole end date
+func parseDateRange(startDate, endDate string) (time.Time, time.Time, error) {
+	start, err := time.ParseInLocation("2006-01-02", startDate, time.Local)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid start date: %w", err)
+	}
+	end, err := time.ParseInLocation("2006-01-02", endDate, time.Local)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid end date: %w", err)
+	}
+	return start, end.AddDate(0, 0, 1).Add(-time.Nanosecond), nil
+}
+
+// GetIncidentTypeCodes returns the standard incident type reference table
+func (a *App) GetIncidentTypeCodes() ([]db.IncidentTypeCode, error) {
+	return a.db.GetIncidentTypeCodes()
+}
+
+// LookupIncidentTypeCode returns a single incident type code, or nil if unknown
+func (a *App) LookupIncidentTypeCode(code string) (*db.IncidentTypeCode, error) {
+	return a.db.GetIncidentTypeCode(code)
+}
+
+// SetPicklistCode assigns a standard code to a call type picklist item
+func (a *App) SetPicklistCode(id int, code, description string) error {
+	if a.currentUser == nil || !a.currentUser.IsAdmin {
+		return ErrUnauthorized
+	}
+	return a.db.SetPicklistCode(id, code, description)
+}
+
+// GetFormFields returns the call form configuration
+func (a *App) GetFormFields() ([]db.FormField, error) {
+	return a.db.GetFormFields()
+}
+
+// UpdateFormField changes a form field's label, required and enabled flags (admin only)
+func (a *App) UpdateFormField(field *db.FormField) error {
+	if a.currentUser == nil || !a.currentUser.IsAdmin {
+		return ErrUnauthorized
+	}
+	return a.db.UpdateFormField(field)
+}
+
+// GetCustomFields returns the active department-specific call fields
+func (a *App) GetCustomFields() ([]db.CustomField, error) {
+	return a.db.GetCustomFields()
+}
+
+// GetAllCustomFields returns every custom field, including inactive ones (admin only)
+func (a *App) GetAllCustomFields() ([]db.CustomField, error) {
+	if a.currentUser == nil || !a.currentUser.IsAdmin {
+		return nil, ErrUnauthorized
+	}
+	return a.db.GetAllCustomFields()
+}
+
+// CreateCustomField defines a new custom call field (admin only)
+func (a *App) CreateCustomField(field *db.CustomField) error {
+	if a.currentUser == nil || !a.currentUser.IsAdmin {
+		return ErrUnauthorized
+	}
+	return a.db.CreateCustomField(field)
+}
+
+// UpdateCustomField updates a custom call field (admin only)
+func (a *App) UpdateCustomField(field *db.CustomField) error {
+	if a.currentUser == nil || !a.currentUser.IsAdmin {
+		return ErrUnauthorized
+	}
+	return a.db.UpdateCustomField(field)
+}
+
+// DeleteCustomField deactivates a custom call field (admin only)
+func (a *App) DeleteCustomField(id int) error {
+	if a.currentUser == nil || !a.currentUser.IsAdmin {
+		return ErrUnauthorized
+	}
+	return a.db.DeleteCustomField(id)
+}
+
 // GetNextCallNumber gets the next call number for the given year
 func (a *App) GetNextCallNumber(year int) (string, error) {
 	return a.db.GetNextCallNumber(year)
 }
 
-// CreateCall creates a new call
-func (a *App) CreateCall(call *db.Call, apparatusIDs []int, responderIDs []int, responderRoles []string) error {
+// CreateCall creates a new call. If possible duplicates exist that are not
+// listed in acknowledgedDuplicateIDs, the call is not saved and a warning is
+// returned; the UI must confirm and call again with the duplicate IDs.
+func (a *App) CreateCall(call *db.Call, apparatusIDs []int, responderIDs []int, responderRoles []string, acknowledgedDuplicateIDs []int) (*db.DuplicateWarning, error) {
 	if a.currentUser == nil {
-		return ErrUnauthorized
+		return nil, ErrUnauthorized
+	}
+
+	duplicates, err := a.db.FindPossibleDuplicates(call, a.db.GetDuplicateWindow())
+	if err != nil {
+		return nil, err
+	}
+
+	acknowledged := make(map[int]bool)
+	for _, id := range acknowledgedDuplicateIDs {
+		acknowledged[id] = true
 	}
+	for _, duplicate := range duplicates {
+		if !acknowledged[duplicate.ID] {
+			return &db.DuplicateWarning{
+				Message:    fmt.Sprintf("%d existing call(s) may be the same incident", len(duplicates)),
+				Duplicates: duplicates,
+			}, nil
+		}
+	}
+
 	call.CreatedBy = a.currentUser.ID
-	return a.db.CreateCall(call, apparatusIDs, responderIDs, responderRoles)
+	return nil, a.db.CreateCall(call, apparatusIDs, responderIDs, responderRoles)
+}
+
+// CheckDuplicateCalls returns existing calls that may be the same incident as call
+func (a *App) CheckDuplicateCalls(call *db.Call) ([]db.Call, error) {
+	if a.currentUser == nil {
+		return nil, ErrUnauthorized
+	}
+	return a.db.FindPossibleDuplicates(call, a.db.GetDuplicateWindow())
+}
+
+// GetDuplicateCallReport returns pairs of saved calls that are likely duplicates
+func (a *App) GetDuplicateCallReport() ([]db.DuplicatePair, error) {
+	if a.currentUser == nil || !a.currentUser.IsAdmin {
+		return nil, ErrUnauthorized
+	}
+	return a.db.FindLikelyDuplicates(a.db.GetDuplicateWindow())
 }
 
 // GetCallByID returns a call by ID
-func (a *App) GetCallByID(id int) (*db.Call, []db.Picklist, []db.User, error) {
+func (a *App) GetCallByID(id int) (*db.Call, []db.Apparatus, []db.User, error) {
 	return a.db.GetCallByID(id)
 }
 
-// GetRecentCalls returns recent calls
-func (a *App) GetRecentCalls(limit int) ([]db.Call, error) {
-	return a.db.GetRecentCalls(limit, 0)
+// GetRecentCalls returns a page of calls, most recently dispatched first.
+// Pass "" as cursor for the first page, then each page's next_cursor.
+func (a *App) GetRecentCalls(cursor string, limit int) (*db.CallPage, error) {
+	return a.db.GetRecentCalls(cursor, limit)
+}
+
+// GetCallsByYear returns a page of the calls for a specific year
+func (a *App) GetCallsByYear(year int, cursor string, limit int) (*db.CallPage, error) {
+	return a.db.GetCallsByYear(year, cursor, limit)
 }
 
-// GetCallsByYear returns all calls for a specific year
-func (a *App) GetCallsByYear(year int) ([]db.Call, error) {
-	return a.db.GetCallsByYear(year)
+// GetCallStats returns call counts for YYYY-MM-DD dates startDate through
+// endDate, narrowed by filter, broken down by call type, town, month, day
+// of week and hour, each compared with the previous period
+func (a *App) GetCallStats(startDate, endDate string, filter db.CallFilter) (*db.CallStats, error) {
+	start, end, err := parseDateRange(startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+	return a.db.GetCallStats(start, end, filter)
 }
 
 // GetCallYears returns all years that have calls
@@ -228,13 +561,78 @@ func (a *App) GetCallYears() ([]int, error) {
 	return a.db.GetCallYears()
 }
 
-// SearchCalls searches for calls
-func (a *App) SearchCalls(query string) ([]db.Call, error) {
-	filters := make(map[string]interface{})
-	if query != "" {
-		filters["query"] = query
+// SearchCalls returns a page of calls matching a search expression such as
+// type:"Structure Fire" town:Stamford after:2026-01-01 responder:smith -mutual
+// (see db.ParseCallQuery). Syntax errors are *db.QueryError with the
+// position of the bad term.
+func (a *App) SearchCalls(query, cursor string, limit int) (*db.CallPage, error) {
+	filter, err := a.db.ParseCallQuery(query)
+	if err != nil {
+		return nil, err
+	}
+	return a.db.SearchCallsPage(filter, cursor, limit)
+}
+
+// SearchCallText full-text searches call narratives, addresses, location
+// notes and towns, best match first with a highlighted snippet. Words must
+// all match; "quoted phrases" match exactly and word* matches a prefix.
+func (a *App) SearchCallText(query, cursor string, limit int) (*db.CallSearchPage, error) {
+	return a.db.SearchCallText(query, db.CallFilter{}, cursor, limit)
+}
+
+// SearchCallMatches returns calls matching a filter with the filtered
+// responders and apparatus that were on each, e.g. every call Engine 2
+// went on last quarter for worker's comp paperwork
+func (a *App) SearchCallMatches(filter db.CallFilter, cursor string, limit int) (*db.CallMatchPage, error) {
+	return a.db.SearchCallMatches(filter, cursor, limit)
+}
+
+// FilterCalls returns calls matching a structured filter
+func (a *App) FilterCalls(filter db.CallFilter, cursor string, limit int) (*db.CallPage, error) {
+	return a.db.SearchCallsPage(filter, cursor, limit)
+}
+
+// SuggestAddresses returns up to 10 previously used addresses that fuzzily
+// match what has been typed, for autocomplete in the call wizard
+func (a *App) SuggestAddresses(prefix string) ([]db.AddressSuggestion, error) {
+	return a.db.SuggestAddresses(prefix, 10)
+}
+
+// SuggestResponders returns up to 10 active members whose names fuzzily
+// match what has been typed
+func (a *App) SuggestResponders(name string) ([]db.User, error) {
+	return a.db.SuggestResponders(name, 10)
+}
+
+// GetPremiseHistory returns a page of earlier calls at the same address and town
+func (a *App) GetPremiseHistory(address, town, cursor string, limit int) (*db.PremiseHistory, error) {
+	return a.db.GetPremiseHistory(address, town, cursor, limit)
+}
+
+// GetCallLinkTypes returns the available link types between calls
+func (a *App) GetCallLinkTypes() []string {
+	return db.CallLinkTypes()
+}
+
+// LinkCalls records that callID is related to linkedCallID (e.g. a rekindle-of it)
+func (a *App) LinkCalls(callID, linkedCallID int, linkType, notes string) (*db.CallLink, error) {
+	if a.currentUser == nil {
+		return nil, ErrUnauthorized
+	}
+	return a.db.CreateCallLink(callID, linkedCallID, linkType, notes, a.currentUser.ID)
+}
+
+// UnlinkCalls removes a link between two calls
+func (a *App) UnlinkCalls(linkID int) error {
+	if a.currentUser == nil {
+		return ErrUnauthorized
 	}
-	return a.db.SearchCalls(filters, 100, 0)
+	return a.db.DeleteCallLink(linkID)
+}
+
+// GetCallLinks returns the calls related to a call
+func (a *App) GetCallLinks(callID int) ([]db.CallLink, error) {
+	return a.db.GetCallLinks(callID)
 }
 
 // UpdateCall updates an existing call
@@ -244,13 +642,667 @@ func (a *App) UpdateCall(call *db.Call, apparatusIDs []int, responderIDs []int,
 
 // DeleteCall marks a call as deleted
 func (a *App) DeleteCall(id int) error {
-	// Just soft delete by updating the call
-	call, _, _, err := a.db.GetCallByID(id)
+	// Note: no DeleteCall method exists, would need to implement soft delete if needed.
+	// Until then the call's apparatus and responders are cleared, without
+	// running the required field checks that UpdateCall applies.
+	return a.db.ClearCallAssignments(id)
+}
+
+// GetNextEventNumber returns the next training/event number for the year
+func (a *App) GetNextEventNumber(year int) (string, error) {
+	return a.db.GetNextEventNumber(year)
+}
+
+// CreateEvent records a training, drill, meeting or detail with its attendance
+func (a *App) CreateEvent(event *db.Event, attendeeIDs []int) error {
+	if a.currentUser == nil {
+		return ErrUnauthorized
+	}
+	event.CreatedBy = a.currentUser.ID
+	return a.db.CreateEvent(event, attendeeIDs)
+}
+
+// UpdateEvent updates an event and its attendance
+func (a *App) UpdateEvent(event *db.Event, attendeeIDs []int) error {
+	if a.currentUser == nil {
+		return ErrUnauthorized
+	}
+	return a.db.UpdateEvent(event, attendeeIDs)
+}
+
+// DeleteEvent removes an event (admin only)
+func (a *App) DeleteEvent(id int) error {
+	if a.currentUser == nil || !a.currentUser.IsAdmin {
+		return ErrUnauthorized
+	}
+	return a.db.DeleteEvent(id)
+}
+
+// GetEventByID returns an event with its attendees
+func (a *App) GetEventByID(id int) (*db.Event, error) {
+	return a.db.GetEventByID(id)
+}
+
+// GetRecentEvents returns a page of the most recent events
+func (a *App) GetRecentEvents(cursor string, limit int) (*db.EventPage, error) {
+	return a.db.GetRecentEvents(cursor, limit)
+}
+
+// SearchEvents returns a page of events matching a number, topic or instructor
+func (a *App) SearchEvents(query, cursor string, limit int) (*db.EventPage, error) {
+	return a.db.SearchEvents(db.EventFilter{Text: query}, cursor, limit)
+}
+
+// FilterEvents returns a page of events matching a structured filter
+func (a *App) FilterEvents(filter db.EventFilter, cursor string, limit int) (*db.EventPage, error) {
+	return a.db.SearchEvents(filter, cursor, limit)
+}
+
+// ExportEventRosterPDF writes the attendance roster for an event to a PDF
+func (a *App) ExportEventRosterPDF(id int, filename string) error {
+	if a.currentUser == nil {
+		return ErrUnauthorized
+	}
+	event, err := a.db.GetEventByID(id)
 	if err != nil {
 		return err
 	}
-	// Note: no DeleteCall method exists, would need to implement soft delete if needed
-	return a.db.UpdateCall(call, []int{}, []int{}, []string{})
+	if event == nil {
+		return errors.New("event not found")
+	}
+	return export.GenerateEventRosterPDF(event, filename)
+}
+
+// GetCallPatients returns the patients recorded on a call
+func (a *App) GetCallPatients(callID int) ([]db.Patient, error) {
+	if a.currentUser == nil {
+		return nil, ErrUnauthorized
+	}
+	return a.db.GetPatientsByCallID(callID)
+}
+
+// AddCallPatient adds a patient record to a call
+func (a *App) AddCallPatient(patient *db.Patient) error {
+	if a.currentUser == nil {
+		return ErrUnauthorized
+	}
+	return a.db.CreatePatient(patient)
+}
+
+// UpdateCallPatient updates a patient record
+func (a *App) UpdateCallPatient(patient *db.Patient) error {
+	if a.currentUser == nil {
+		return ErrUnauthorized
+	}
+	return a.db.UpdatePatient(patient)
+}
+
+// DeleteCallPatient removes a patient record from a call
+func (a *App) DeleteCallPatient(id int) error {
+	if a.currentUser == nil {
+		return ErrUnauthorized
+	}
+	return a.db.DeletePatient(id)
+}
+
+// GetCallParties returns the people involved in a call. Their personal
+// information is blanked for users who are not admins.
+func (a *App) GetCallParties(callID int) ([]db.Party, error) {
+	if a.currentUser == nil {
+		return nil, ErrUnauthorized
+	}
+	parties, err := a.db.GetPartiesByCallID(callID)
+	if err != nil {
+		return nil, err
+	}
+	if !a.currentUser.IsAdmin {
+		db.RedactPII(parties)
+	}
+	return parties, nil
+}
+
+// GetPartyPIIFields returns the party fields that hold personal information
+func (a *App) GetPartyPIIFields() []string {
+	return db.PIIFields(db.Party{})
+}
+
+// AddCallParty adds a person involved in a call
+func (a *App) AddCallParty(party *db.Party) error {
+	if a.currentUser == nil {
+		return ErrUnauthorized
+	}
+	return a.db.CreateParty(party)
+}
+
+// UpdateCallParty updates a person involved in a call (admin only, since
+// other users only see redacted details)
+func (a *App) UpdateCallParty(party *db.Party) error {
+	if a.currentUser == nil || !a.currentUser.IsAdmin {
+		return ErrUnauthorized
+	}
+	return a.db.UpdateParty(party)
+}
+
+// DeleteCallParty removes a person from a call (admin only)
+func (a *App) DeleteCallParty(id int) error {
+	if a.currentUser == nil || !a.currentUser.IsAdmin {
+		return ErrUnauthorized
+	}
+	return a.db.DeleteParty(id)
+}
+
+// GetMVAVehicles returns the vehicles recorded on an MVA call. Plates are
+// blanked for users who are not admins.
+func (a *App) GetMVAVehicles(callID int) ([]db.MVAVehicle, error) {
+	if a.currentUser == nil {
+		return nil, ErrUnauthorized
+	}
+	vehicles, err := a.db.GetMVAVehicles(callID)
+	if err != nil {
+		return nil, err
+	}
+	if !a.currentUser.IsAdmin {
+		db.RedactPII(vehicles)
+	}
+	return vehicles, nil
+}
+
+// AddMVAVehicle adds a vehicle to an MVA call
+func (a *App) AddMVAVehicle(vehicle *db.MVAVehicle) error {
+	if a.currentUser == nil {
+		return ErrUnauthorized
+	}
+	return a.db.CreateMVAVehicle(vehicle)
+}
+
+// UpdateMVAVehicle updates a vehicle and its linked parties (admin only,
+// since other users only see redacted details)
+func (a *App) UpdateMVAVehicle(vehicle *db.MVAVehicle) error {
+	if a.currentUser == nil || !a.currentUser.IsAdmin {
+		return ErrUnauthorized
+	}
+	return a.db.UpdateMVAVehicle(vehicle)
+}
+
+// DeleteMVAVehicle removes a vehicle from a call (admin only)
+func (a *App) DeleteMVAVehicle(id int) error {
+	if a.currentUser == nil || !a.currentUser.IsAdmin {
+		return ErrUnauthorized
+	}
+	return a.db.DeleteMVAVehicle(id)
+}
+
+// GetMVAsByLocation reports MVAs on a road, optionally within a town, a
+// house number range and a date range (YYYY-MM-DD, either may be empty)
+func (a *App) GetMVAsByLocation(road, town string, fromNumber, toNumber int, startDate, endDate string) (*db.MVALocationReport, error) {
+	var start, end time.Time
+	var err error
+	if startDate != "" {
+		if start, _, err = parseDateRange(startDate, startDate); err != nil {
+			return nil, err
+		}
+	}
+	if endDate != "" {
+		if _, end, err = parseDateRange(endDate, endDate); err != nil {
+			return nil, err
+		}
+	}
+	report, err := a.db.GetMVAsByLocation(road, town, fromNumber, toNumber, start, end)
+	if err != nil {
+		return nil, err
+	}
+	if a.currentUser == nil || !a.currentUser.IsAdmin {
+		db.RedactPII(report)
+	}
+	return report, nil
+}
+
+// GetFireDetails returns the fire cause and loss record for a call, or nil if none
+func (a *App) GetFireDetails(callID int) (*db.FireDetails, error) {
+	if a.currentUser == nil {
+		return nil, ErrUnauthorized
+	}
+	return a.db.GetFireDetails(callID)
+}
+
+// SaveFireDetails creates or updates the fire cause and loss record for a call
+func (a *App) SaveFireDetails(details *db.FireDetails) error {
+	if a.currentUser == nil {
+		return ErrUnauthorized
+	}
+	return a.db.SaveFireDetails(details)
+}
+
+// GetSettings returns all application settings
+func (a *App) GetSettings() ([]db.Setting, error) {
+	return a.db.GetSettings()
+}
+
+// UpdateSetting changes an application setting
+func (a *App) UpdateSetting(key, value string) error {
+	if a.currentUser == nil || !a.currentUser.IsAdmin {
+		return ErrUnauthorized
+	}
+	return a.db.UpdateSetting(key, value)
+}
+
+// GetNarrativeTemplates returns the active narrative templates for a call type
+func (a *App) GetNarrativeTemplates(callType string) ([]db.NarrativeTemplate, error) {
+	return a.db.GetNarrativeTemplates(callType)
+}
+
+// GetAllNarrativeTemplates returns every narrative template (admin only)
+func (a *App) GetAllNarrativeTemplates() ([]db.NarrativeTemplate, error) {
+	if a.currentUser == nil || !a.currentUser.IsAdmin {
+		return nil, ErrUnauthorized
+	}
+	return a.db.GetAllNarrativeTemplates()
+}
+
+// RenderNarrativeTemplate fills a template from the call being entered
+func (a *App) RenderNarrativeTemplate(templateID int, call *db.Call, apparatusIDs []int, responderIDs []int) (string, error) {
+	return a.db.RenderNarrativeTemplate(templateID, call, apparatusIDs, responderIDs)
+}
+
+// CreateNarrativeTemplate creates a narrative template (admin only)
+func (a *App) CreateNarrativeTemplate(template *db.NarrativeTemplate) error {
+	if a.currentUser == nil || !a.currentUser.IsAdmin {
+		return ErrUnauthorized
+	}
+	return a.db.CreateNarrativeTemplate(template)
+}
+
+// UpdateNarrativeTemplate updates a narrative template (admin only)
+func (a *App) UpdateNarrativeTemplate(template *db.NarrativeTemplate) error {
+	if a.currentUser == nil || !a.currentUser.IsAdmin {
+		return ErrUnauthorized
+	}
+	return a.db.UpdateNarrativeTemplate(template)
+}
+
+// DeleteNarrativeTemplate deactivates a narrative template (admin only)
+func (a *App) DeleteNarrativeTemplate(id int) error {
+	if a.currentUser == nil || !a.currentUser.IsAdmin {
+		return ErrUnauthorized
+	}
+	return a.db.DeleteNarrativeTemplate(id)
+}
+
+// UploadAttachment attaches a photo or document to a call
+func (a *App) UploadAttachment(callID int, fileName string, data []byte, mimeType, caption string) (*db.Attachment, error) {
+	if a.currentUser == nil {
+		return nil, ErrUnauthorized
+	}
+	return a.db.CreateAttachment(callID, fileName, mimeType, caption, data, a.currentUser.ID)
+}
+
+// GetCallAttachments lists the attachments on a call without file data
+func (a *App) GetCallAttachments(callID int) ([]db.Attachment, error) {
+	if a.currentUser == nil {
+		return nil, ErrUnauthorized
+	}
+	return a.db.GetAttachmentsByCallID(callID)
+}
+
+// DownloadAttachment returns an attachment with its file data
+func (a *App) DownloadAttachment(id int) (*db.Attachment, error) {
+	if a.currentUser == nil {
+		return nil, ErrUnauthorized
+	}
+	attachment, err := a.db.GetAttachment(id)
+	if err != nil {
+		return nil, err
+	}
+	if attachment == nil {
+		return nil, errors.New("attachment not found")
+	}
+	return attachment, nil
+}
+
+// DeleteAttachment soft-deletes an attachment (admin or the uploader only)
+func (a *App) DeleteAttachment(id int) error {
+	if a.currentUser == nil {
+		return ErrUnauthorized
+	}
+	attachment, err := a.db.GetAttachment(id)
+	if err != nil {
+		return err
+	}
+	if attachment == nil {
+		return errors.New("attachment not found")
+	}
+	if !a.currentUser.IsAdmin && attachment.UploadedBy != a.currentUser.ID {
+		return ErrUnauthorized
+	}
+	return a.db.DeleteAttachment(id, a.currentUser.ID)
+}
+
+// BulkSetCallField sets a field on every call matching filter (admin only).
+// With dryRun set, nothing is changed and the affected calls are returned.
+func (a *App) BulkSetCallField(filter db.CallFilter, field, value string, dryRun bool) (*db.BulkResult, error) {
+	if a.currentUser == nil || !a.currentUser.IsAdmin {
+		return nil, ErrUnauthorized
+	}
+	return a.db.BulkSetCallField(filter, field, value, a.currentUser.ID, dryRun)
+}
+
+// BulkAddApparatus adds an apparatus to every call matching filter (admin only)
+func (a *App) BulkAddApparatus(filter db.CallFilter, apparatusID int, dryRun bool) (*db.BulkResult, error) {
+	if a.currentUser == nil || !a.currentUser.IsAdmin {
+		return nil, ErrUnauthorized
+	}
+	return a.db.BulkAddApparatus(filter, apparatusID, a.currentUser.ID, dryRun)
+}
+
+// BulkRemoveApparatus removes an apparatus from every call matching filter (admin only)
+func (a *App) BulkRemoveApparatus(filter db.CallFilter, apparatusID int, dryRun bool) (*db.BulkResult, error) {
+	if a.currentUser == nil || !a.currentUser.IsAdmin {
+		return nil, ErrUnauthorized
+	}
+	return a.db.BulkRemoveApparatus(filter, apparatusID, a.currentUser.ID, dryRun)
+}
+
+// BulkAddResponder adds a responder to every call matching filter (admin only)
+func (a *App) BulkAddResponder(filter db.CallFilter, responderID int, role string, dryRun bool) (*db.BulkResult, error) {
+	if a.currentUser == nil || !a.currentUser.IsAdmin {
+		return nil, ErrUnauthorized
+	}
+	return a.db.BulkAddResponder(filter, responderID, role, a.currentUser.ID, dryRun)
+}
+
+// BulkRemoveResponder removes a responder from every call matching filter (admin only)
+func (a *App) BulkRemoveResponder(filter db.CallFilter, responderID int, dryRun bool) (*db.BulkResult, error) {
+	if a.currentUser == nil || !a.currentUser.IsAdmin {
+		return nil, ErrUnauthorized
+	}
+	return a.db.BulkRemoveResponder(filter, responderID, a.currentUser.ID, dryRun)
+}
+
+// BulkReassignCreatedBy changes the creator of every call matching filter (admin only)
+func (a *App) BulkReassignCreatedBy(filter db.CallFilter, newUserID int, dryRun bool) (*db.BulkResult, error) {
+	if a.currentUser == nil || !a.currentUser.IsAdmin {
+		return nil, ErrUnauthorized
+	}
+	return a.db.BulkReassignCreatedBy(filter, newUserID, a.currentUser.ID, dryRun)
+}
+
+// GetSavedFilters returns the current user's saved filters and the
+// department's shared ones
+func (a *App) GetSavedFilters() ([]db.SavedFilter, error) {
+	if a.currentUser == nil {
+		return nil, ErrUnauthorized
+	}
+	return a.db.GetSavedFilters(a.currentUser.ID)
+}
+
+// CreateSavedFilter saves a named filter for the current user. Only admins
+// can share a filter department-wide.
+func (a *App) CreateSavedFilter(name string, filter db.CallFilter, shared bool) (*db.SavedFilter, error) {
+	if a.currentUser == nil || (shared && !a.currentUser.IsAdmin) {
+		return nil, ErrUnauthorized
+	}
+	saved := &db.SavedFilter{Name: name, OwnerID: a.currentUser.ID, Shared: shared, Filter: filter}
+	if err := a.db.CreateSavedFilter(saved); err != nil {
+		return nil, err
+	}
+	return saved, nil
+}
+
+// UpdateSavedFilter changes a saved filter (owner or admin). Only admins
+// can share a filter department-wide.
+func (a *App) UpdateSavedFilter(saved db.SavedFilter) error {
+	existing, err := a.editableSavedFilter(saved.ID)
+	if err != nil {
+		return err
+	}
+	if saved.Shared && !a.currentUser.IsAdmin {
+		return ErrUnauthorized
+	}
+	saved.OwnerID = existing.OwnerID
+	return a.db.UpdateSavedFilter(&saved)
+}
+
+// DeleteSavedFilter removes a saved filter (owner or admin)
+func (a *App) DeleteSavedFilter(id int) error {
+	if _, err := a.editableSavedFilter(id); err != nil {
+		return err
+	}
+	return a.db.DeleteSavedFilter(id)
+}
+
+// GetDefaultSavedFilter returns the current user's default call list view,
+// or nil if they have none
+func (a *App) GetDefaultSavedFilter() (*db.SavedFilter, error) {
+	if a.currentUser == nil {
+		return nil, ErrUnauthorized
+	}
+	return a.db.GetDefaultSavedFilter(a.currentUser.ID)
+}
+
+// SetDefaultSavedFilter makes one of the current user's own or shared saved
+// filters their default call list view. An id of 0 clears the default.
+func (a *App) SetDefaultSavedFilter(id int) error {
+	if a.currentUser == nil {
+		return ErrUnauthorized
+	}
+	if id != 0 {
+		if _, err := a.visibleSavedFilter(id); err != nil {
+			return err
+		}
+	}
+	return a.db.SetDefaultSavedFilter(a.currentUser.ID, id)
+}
+
+// editableSavedFilter returns a saved filter the current user may change:
+// their own, or any filter for admins
+func (a *App) editableSavedFilter(id int) (*db.SavedFilter, error) {
+	if a.currentUser == nil {
+		return nil, ErrUnauthorized
+	}
+	saved, err := a.db.GetSavedFilter(id)
+	if err != nil {
+		return nil, err
+	}
+	if saved == nil {
+		return nil, errors.New("saved filter not found")
+	}
+	if saved.OwnerID != a.currentUser.ID && !a.currentUser.IsAdmin {
+		return nil, ErrUnauthorized
+	}
+	return saved, nil
+}
+
+// visibleSavedFilter returns a saved filter the current user may run:
+// their own or a shared one
+func (a *App) visibleSavedFilter(id int) (*db.SavedFilter, error) {
+	if a.currentUser == nil {
+		return nil, ErrUnauthorized
+	}
+	saved, err := a.db.GetSavedFilter(id)
+	if err != nil {
+		return nil, err
+	}
+	if saved == nil {
+		return nil, errors.New("saved filter not found")
+	}
+	if saved.OwnerID != a.currentUser.ID && !saved.Shared {
+		return nil, ErrUnauthorized
+	}
+	return saved, nil
+}
+
+// RunSavedFilter returns a page of the calls matching a saved filter
+func (a *App) RunSavedFilter(id int, cursor string, limit int) (*db.CallPage, error) {
+	saved, err := a.visibleSavedFilter(id)
+	if err != nil {
+		return nil, err
+	}
+	return a.db.SearchCallsPage(saved.Filter, cursor, limit)
+}
+
+// ExportSavedFilterCSV writes every call matching a saved filter to a CSV file
+func (a *App) ExportSavedFilterCSV(id int, filename string) error {
+	saved, err := a.visibleSavedFilter(id)
+	if err != nil {
+		return err
+	}
+	return a.ExportCallsCSV(saved.Filter, filename)
+}
+
+// ExportSavedFilterPDF writes every call matching a saved filter to a
+// call log PDF
+func (a *App) ExportSavedFilterPDF(id int, filename string) error {
+	saved, err := a.visibleSavedFilter(id)
+	if err != nil {
+		return err
+	}
+	return a.ExportCallLogPDF(saved.Filter, filename)
+}
+
+// exportPageSize is how many calls exports read from the database at a time
+const exportPageSize = 500
+
+// ExportCallsCSV writes every call matching filter to a CSV file with their
+// links, custom fields, supplies and parties, a page at a time. Party PII
+// and patient care data are only included for admins.
+func (a *App) ExportCallsCSV(filter db.CallFilter, filename string) error {
+	if a.currentUser == nil {
+		return ErrUnauthorized
+	}
+	customFields, err := a.db.GetCustomFields()
+	if err != nil {
+		return err
+	}
+
+	// Empty maps switch the optional columns on; each page fills its own
+	opts := export.CSVOptions{
+		CustomFields:    customFields,
+		InventoryUsage:  map[int][]db.InventoryUsage{},
+		Parties:         map[int][]db.Party{},
+		IncludePII:      a.currentUser.IsAdmin,
+		IncludePatients: a.currentUser.IsAdmin,
+	}
+	w, err := export.NewCallCSVWriter(filename, opts)
+	if err != nil {
+		return err
+	}
+
+	err = a.db.ForEachCallPage(filter, exportPageSize, func(calls []db.Call) error {
+		details, err := a.loadCallDetails(calls)
+		if err != nil {
+			return err
+		}
+		return w.WriteCalls(calls, details)
+	})
+	if err != nil {
+		w.Close()
+		return err
+	}
+	return w.Close()
+}
+
+// loadCallDetails attaches links and custom values to a page of calls and
+// loads the related records the CSV export needs
+func (a *App) loadCallDetails(calls []db.Call) (export.CallDetails, error) {
+	var details export.CallDetails
+	if err := a.db.AttachCallLinks(calls); err != nil {
+		return details, err
+	}
+	if err := a.db.AttachCustomValues(calls); err != nil {
+		return details, err
+	}
+
+	callIDs := make([]int, len(calls))
+	for i, call := range calls {
+		callIDs[i] = call.ID
+	}
+	var err error
+	if details.InventoryUsage, err = a.db.GetInventoryUsageForCalls(callIDs); err != nil {
+		return details, err
+	}
+	if details.Parties, err = a.db.GetPartiesForCalls(callIDs); err != nil {
+		return details, err
+	}
+	if a.currentUser.IsAdmin {
+		if details.Patients, err = a.db.GetPatientsForCalls(callIDs); err != nil {
+			return details, err
+		}
+	}
+	return details, nil
+}
+
+// GetFireLossTotals sums fire losses for calls dispatched between
+// YYYY-MM-DD dates startDate and endDate
+func (a *App) GetFireLossTotals(startDate, endDate string) (*db.FireLossTotals, error) {
+	start, end, err := parseDateRange(startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+	return a.db.GetFireLossTotals(start, end)
+}
+
+// ExportSummaryPDF writes the summary report for calls dispatched between
+// YYYY-MM-DD dates startDate and endDate, with fire loss totals
+func (a *App) ExportSummaryPDF(startDate, endDate, filename string) error {
+	if a.currentUser == nil {
+		return ErrUnauthorized
+	}
+	start, end, err := parseDateRange(startDate, endDate)
+	if err != nil {
+		return err
+	}
+
+	var calls []db.Call
+	filter := db.CallFilter{DispatchedFrom: &start, DispatchedTo: &end}
+	err = a.db.ForEachCallPage(filter, exportPageSize, func(page []db.Call) error {
+		calls = append(calls, page...)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	losses, err := a.db.GetFireLossTotals(start, end)
+	if err != nil {
+		return err
+	}
+	return export.GenerateSummaryPDF(calls, losses, filename, start.Format("01/02/2006"), end.Format("01/02/2006"))
+}
+
+// ExportCallLogPDF writes every call matching filter to a call log PDF
+func (a *App) ExportCallLogPDF(filter db.CallFilter, filename string) error {
+	if a.currentUser == nil {
+		return ErrUnauthorized
+	}
+	start, end := filterPeriod(filter)
+	callLog := export.NewCallLogPDF(start, end)
+	err := a.db.ForEachCallPage(filter, exportPageSize, func(calls []db.Call) error {
+		callLog.AddCalls(calls)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	return callLog.Save(filename)
+}
+
+// filterPeriod describes a filter's dispatch range for report headers
+func filterPeriod(filter db.CallFilter) (string, string) {
+	start, end := "all calls", "present"
+	if filter.DispatchedFrom != nil {
+		start = filter.DispatchedFrom.Format("01/02/2006")
+	}
+	if filter.DispatchedTo != nil {
+		end = filter.DispatchedTo.Format("01/02/2006")
+	}
+	return start, end
+}
+
+// GetAuditLog returns a page of recent audit log entries (admin only)
+func (a *App) GetAuditLog(cursor string, limit int) (*db.AuditLogPage, error) {
+	if a.currentUser == nil || !a.currentUser.IsAdmin {
+		return nil, ErrUnauthorized
+	}
+	return a.db.GetAuditLog(cursor, limit)
 }
 
 // UploadLogo uploads and stores a logo image
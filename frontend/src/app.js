@@ -425,7 +425,7 @@ function backToMenu() {
 
 // New Call
 let currentWizardStep = 1;
-const totalWizardSteps = 12;
+const totalWizardSteps = 13;
 
 async function showNewCall() {
     showScreen('newcall-screen');
@@ -500,33 +500,15 @@ function setDispatchedDate(option) {
 }
 
 async function loadPicklists() {
-    const categories = ['call_type', 'mutual_aid', 'mutual_aid_agencies', 'town', 'apparatus'];
+    await loadApparatus();
+    
+    const categories = ['call_type', 'mutual_aid', 'mutual_aid_agencies', 'town', 'weather_wind', 'precipitation', 'road_conditions'];
     
     for (const category of categories) {
         try {
             const items = await window.go.main.App.GetPicklistByCategory(category);
             picklists[category] = items;
             
-            // Handle apparatus checkboxes
-            if (category === 'apparatus') {
-                const apparatusDiv = document.getElementById('apparatus-checkboxes');
-                if (apparatusDiv) {
-                    apparatusDiv.innerHTML = '';
-                    items.forEach(item => {
-                        const label = document.createElement('label');
-                        label.style.display = 'flex';
-                        label.style.alignItems = 'center';
-                        label.style.cursor = 'pointer';
-                        label.innerHTML = `
-                            <input type="checkbox" name="apparatus" value="${item.id}" style="margin-right: 10px; width: 20px; height: 20px;">
-                            <span style="font-size: 1.1em;">${item.value}</span>
-                        `;
-                        apparatusDiv.appendChild(label);
-                    });
-                }
-                continue;
-            }
-            
             // Handle mutual aid agencies with datalist
             if (category === 'mutual_aid_agencies') {
                 const input = document.getElementById('q-mutual-aid-agencies-input');
@@ -578,6 +560,30 @@ async function loadPicklists() {
     }
 }
 
+async function loadApparatus() {
+    try {
+        const units = await window.go.main.App.GetActiveApparatus();
+        const apparatusDiv = document.getElementById('apparatus-checkboxes');
+        if (!apparatusDiv) return;
+        
+        apparatusDiv.innerHTML = '';
+        (units || []).forEach(unit => {
+            const label = document.createElement('label');
+            label.style.display = 'flex';
+            label.style.alignItems = 'center';
+            label.style.cursor = 'pointer';
+            const status = unit.in_service ? '' : ' (Out of Service)';
+            label.innerHTML = `
+                <input type="checkbox" name="apparatus" value="${unit.id}" style="margin-right: 10px; width: 20px; height: 20px;">
+                <span style="font-size: 1.1em;">${unit.name}${status}</span>
+            `;
+            apparatusDiv.appendChild(label);
+        });
+    } catch (error) {
+        console.error('Failed to load apparatus:', error);
+    }
+}
+
 function updateDispatchedValue() {
     const dateValue = document.getElementById('q-dispatched-date').value;
     const timeValue = document.getElementById('q-dispatched-time').value;
@@ -597,7 +603,7 @@ function handleMutualAidChange() {
     const mutualAidValue = document.getElementById('q-mutual-aid').value;
     const agenciesCard = document.querySelector('[data-step="3.5"]');
     
-    if (mutualAidValue === 'Yes') {
+    if (mutualAidValue === 'Given' || mutualAidValue === 'Received') {
         agenciesCard.style.display = 'block';
     } else {
         agenciesCard.style.display = 'none';
@@ -808,6 +814,20 @@ function wizardNext() {
     } else if (field === 'mutual-aid-agencies') {
         // Agencies are already saved in the hidden input via updateSelectedAgenciesDisplay
         // No additional action needed here
+    } else if (field === 'conditions') {
+        // Handle weather and road conditions (all optional)
+        const temperature = document.getElementById('q-temperature').value;
+        if (temperature !== '' && (temperature < -60 || temperature > 130)) {
+            alert('Temperature must be between -60 and 130 °F');
+            return;
+        }
+        const conditions = [
+            temperature !== '' ? `${temperature}°F` : '',
+            document.getElementById('q-weather-wind').value,
+            document.getElementById('q-precipitation').value,
+            document.getElementById('q-road-conditions').value
+        ].filter(v => v);
+        updateSummary(field, conditions.join(', '));
     } else if (field === 'apparatus') {
         // Handle apparatus checkboxes
         const checkedBoxes = document.querySelectorAll('input[name="apparatus"]:checked');
@@ -939,10 +959,10 @@ function wizardNext() {
     // Determine next step
     let nextStep = currentWizardStep + 1;
     
-    // Skip step 3b (agencies) if mutual aid is not "Yes"
+    // Skip step 3b (agencies) if mutual aid was neither given nor received
     if (currentWizardStep === 3 && nextStep === 3.5) {
         const mutualAidValue = document.getElementById('mutual-aid').value;
-        if (mutualAidValue !== 'Yes') {
+        if (mutualAidValue !== 'Given' && mutualAidValue !== 'Received') {
             nextStep = 4; // Skip to step 4
         }
     }
@@ -958,10 +978,10 @@ function wizardPrevious() {
     if (currentWizardStep > 1) {
         let prevStep = currentWizardStep - 1;
         
-        // Skip step 3b (agencies) if mutual aid is not "Yes" when going backwards
+        // Skip step 3b (agencies) if mutual aid was neither given nor received when going backwards
         if (currentWizardStep === 4 && prevStep === 3.5) {
             const mutualAidValue = document.getElementById('mutual-aid').value;
-            if (mutualAidValue !== 'Yes') {
+            if (mutualAidValue !== 'Given' && mutualAidValue !== 'Received') {
                 prevStep = 3; // Skip back to step 3
             }
         }
@@ -983,6 +1003,7 @@ function updateSummary(field, value) {
         'enroute': 'enroute',
         'on-scene': 'onscene',
         'clear': 'clear',
+        'conditions': 'conditions',
         'narrative': 'narrative'
     };
     
@@ -1030,8 +1051,52 @@ document.addEventListener('DOMContentLoaded', function() {
             updateSummary(field, this.value);
         });
     });
+    
+    setupAddressSuggestions();
 });
 
+// Suggest previously used addresses as the address is typed, tolerating
+// typos like "Mian St", and fill in the town of a picked suggestion
+function setupAddressSuggestions() {
+    const input = document.getElementById('q-address');
+    if (!input) return;
+    
+    const datalist = document.createElement('datalist');
+    datalist.id = 'q-address-list';
+    input.parentNode.appendChild(datalist);
+    input.setAttribute('list', datalist.id);
+    
+    let towns = {};
+    let timer = null;
+    input.addEventListener('input', function() {
+        const townInput = document.getElementById('q-town');
+        if (towns[this.value] && townInput && !townInput.value) {
+            townInput.value = towns[this.value];
+            updateSummary('town', townInput.value);
+        }
+        
+        clearTimeout(timer);
+        const prefix = this.value;
+        if (prefix.trim().length < 2) return;
+        timer = setTimeout(async () => {
+            try {
+                const suggestions = await window.go.main.App.SuggestAddresses(prefix);
+                towns = {};
+                datalist.innerHTML = '';
+                (suggestions || []).forEach(s => {
+                    const option = document.createElement('option');
+                    option.value = s.address;
+                    option.label = s.town ? `${s.town} (${s.call_count} calls)` : `${s.call_count} calls`;
+                    datalist.appendChild(option);
+                    towns[s.address] = s.town;
+                });
+            } catch (error) {
+                console.error('Failed to load address suggestions:', error);
+            }
+        }, 200);
+    });
+}
+
 async function saveCall() {
     // Save the narrative value from the textarea to the hidden input
     const narrativeTextarea = document.getElementById('q-narrative');
@@ -1066,21 +1131,39 @@ async function saveCall() {
         const responderCheckboxes = document.querySelectorAll('input[name="responders"]:checked');
         const responderIDs = Array.from(responderCheckboxes).map(cb => parseInt(cb.value));
         
+        // Keys are the db.Call json tags
         const call = {
-            CallType: callType,
-            MutualAid: document.getElementById('mutual-aid').value,
-            Address: address,
-            Town: document.getElementById('town').value,
-            LocationNotes: document.getElementById('location-notes').value,
-            Dispatched: dispatchedDateTime.toISOString(),
-            Enroute: getTimeOrNull('enroute', dispatchedDateTime),
-            OnScene: getTimeOrNull('on-scene', dispatchedDateTime),
-            Clear: getTimeOrNull('clear', dispatchedDateTime),
-            Narrative: narrative,
-            CreatedBy: currentUser.id
+            call_type: callType,
+            mutual_aid: document.getElementById('mutual-aid').value,
+            address: address,
+            town: document.getElementById('town').value,
+            location_notes: document.getElementById('location-notes').value,
+            dispatched: dispatchedDateTime.toISOString(),
+            enroute: getTimeOrNull('enroute', dispatchedDateTime),
+            on_scene: getTimeOrNull('on-scene', dispatchedDateTime),
+            clear: getTimeOrNull('clear', dispatchedDateTime),
+            narrative: narrative,
+            temperature_f: getTemperatureOrNull(),
+            wind: document.getElementById('q-weather-wind').value,
+            precipitation: document.getElementById('q-precipitation').value,
+            road_conditions: document.getElementById('q-road-conditions').value,
+            created_by: currentUser.id
         };
         
-        await window.go.main.App.CreateCall(call, apparatusIDs, responderIDs, []);
+        let warning = await window.go.main.App.CreateCall(call, apparatusIDs, responderIDs, [], []);
+        if (warning) {
+            const list = warning.duplicates.map(d =>
+                `${d.incident_number || 'N/A'} - ${d.call_type} at ${d.address} (${new Date(d.dispatched).toLocaleString()})`
+            ).join('\n');
+            if (!confirm(`${warning.message}:\n\n${list}\n\nSave this call anyway?`)) {
+                return;
+            }
+            warning = await window.go.main.App.CreateCall(call, apparatusIDs, responderIDs, [], warning.duplicates.map(d => d.id));
+            if (warning) {
+                alert('Another possible duplicate was saved in the meantime. Please review and save again.');
+                return;
+            }
+        }
         alert('Call saved successfully!');
         clearNewCallForm();
         showMainMenu();
@@ -1096,6 +1179,12 @@ function getTimeOrNull(elementId, dispatchedDateTime) {
     return new Date(value).toISOString();
 }
 
+function getTemperatureOrNull() {
+    const value = document.getElementById('q-temperature').value;
+    if (value === '') return null;
+    return parseInt(value, 10);
+}
+
 function clearNewCallForm() {
     // Clear all question inputs
     document.getElementById('q-call-type').value = '';
@@ -1116,6 +1205,10 @@ function clearNewCallForm() {
     document.getElementById('q-on-scene-time').value = '';
     document.getElementById('q-clear-date').value = '';
     document.getElementById('q-clear-time').value = '';
+    document.getElementById('q-temperature').value = '';
+    document.getElementById('q-weather-wind').value = '';
+    document.getElementById('q-precipitation').value = '';
+    document.getElementById('q-road-conditions').value = '';
     document.getElementById('q-narrative').value = '';
     
     // Clear selected agencies
@@ -1204,7 +1297,13 @@ async function loadCallsByYear() {
     const year = parseInt(document.getElementById('year-selector').value);
     
     try {
-        const calls = await window.go.main.App.GetCallsByYear(year);
+        const calls = [];
+        let cursor = '';
+        do {
+            const page = await window.go.main.App.GetCallsByYear(year, cursor, 500);
+            calls.push(...(page.items || []));
+            cursor = page.next_cursor;
+        } while (cursor);
         const listDiv = document.getElementById('calls-list');
         listDiv.innerHTML = '';
         
@@ -1219,26 +1318,15 @@ async function loadCallsByYear() {
             return;
         }
         
-        // Calculate statistics
-        const stats = {
-            total: calls.length,
-            mutualAidGiven: calls.filter(c => c.mutual_aid === 'Yes').length,
-            mutualAidReceived: calls.filter(c => c.mutual_aid === 'Received').length,
-            callTypes: {}
-        };
-        
-        calls.forEach(call => {
-            stats.callTypes[call.call_type] = (stats.callTypes[call.call_type] || 0) + 1;
-        });
-        
-        const mostCommonType = Object.keys(stats.callTypes).reduce((a, b) => 
-            stats.callTypes[a] > stats.callTypes[b] ? a : b, '-');
+        // Statistics are counted in SQL, with the previous year for comparison
+        const stats = await window.go.main.App.GetCallStats(`${year}-01-01`, `${year}-12-31`, {});
         
         // Update statistics display
-        document.getElementById('stat-total').textContent = stats.total;
-        document.getElementById('stat-mutual-aid-given').textContent = stats.mutualAidGiven;
-        document.getElementById('stat-mutual-aid-received').textContent = stats.mutualAidReceived;
-        document.getElementById('stat-common-type').textContent = stats.total > 0 ? mostCommonType : '-';
+        document.getElementById('stat-total').textContent = stats.total.current;
+        document.getElementById('stat-mutual-aid-given').textContent = stats.mutual_aid_given.current;
+        document.getElementById('stat-mutual-aid-received').textContent = stats.mutual_aid_received.current;
+        document.getElementById('stat-common-type').textContent =
+            stats.by_call_type && stats.by_call_type.length > 0 && stats.by_call_type[0].current > 0 ? stats.by_call_type[0].key : '-';
         
         calls.forEach(call => {
             const callDiv = document.createElement('div');
@@ -1309,7 +1397,8 @@ async function performSearch() {
     }
     
     try {
-        const calls = await window.go.main.App.SearchCalls(query);
+        const page = await window.go.main.App.SearchCalls(query, '', 100);
+        const calls = page.items || [];
         const resultsDiv = document.getElementById('search-results');
         resultsDiv.innerHTML = '';
         
@@ -1350,7 +1439,14 @@ function showExport() {
 
 async function exportCSV() {
     try {
-        const calls = await window.go.main.App.GetRecentCalls(1000);
+        // Read every page so large logs are not cut off
+        const calls = [];
+        let cursor = '';
+        do {
+            const page = await window.go.main.App.GetRecentCalls(cursor, 500);
+            calls.push(...(page.items || []));
+            cursor = page.next_cursor;
+        } while (cursor);
         
         // Create CSV content
         let csv = 'Incident Number,Call Type,Address,Town,Dispatched,Disposition,Narrative\n';
@@ -1672,6 +1768,10 @@ async function showAdminPicklists() {
 async function loadPicklistItems() {
     const category = document.getElementById('picklist-category').value;
     if (!category) return;
+    if (category === 'apparatus') {
+        await loadApparatusItems();
+        return;
+    }
     
     try {
         const items = await window.go.main.App.GetPicklistByCategory(category);
@@ -1703,12 +1803,72 @@ async function loadPicklistItems() {
     }
 }
 
+async function loadApparatusItems() {
+    const listDiv = document.getElementById('picklist-items');
+    try {
+        const units = await window.go.main.App.GetApparatus();
+        listDiv.innerHTML = '';
+        
+        if (!units || units.length === 0) {
+            listDiv.innerHTML = '<p>No items found</p>';
+            return;
+        }
+        
+        units.forEach(unit => {
+            const itemDiv = document.createElement('div');
+            itemDiv.className = 'call-item';
+            itemDiv.innerHTML = `
+                <div class="call-header">
+                    <span class="call-number">${unit.name}</span>
+                    <span class="call-type">${unit.unit_type || '-'}</span>
+                </div>
+                <div class="call-details">
+                    <div><strong>Station:</strong> ${unit.station || '-'}</div>
+                    <div><strong>Seats:</strong> ${unit.seats} &nbsp; <strong>Pump:</strong> ${unit.pump_gpm ? unit.pump_gpm + ' GPM' : 'None'}</div>
+                    <div><strong>Status:</strong> ${!unit.active ? 'Retired' : unit.in_service ? 'In Service' : 'Out of Service'}</div>
+                </div>
+            `;
+            listDiv.appendChild(itemDiv);
+        });
+    } catch (error) {
+        console.error('Failed to load apparatus:', error);
+        listDiv.innerHTML = '<p>No items found</p>';
+    }
+}
+
+function showAddApparatus() {
+    const name = prompt('Enter unit name (e.g. Engine 3):');
+    if (!name) return;
+    
+    const unit = {
+        name: name,
+        unit_type: prompt('Enter unit type (Engine, Ladder/Truck, Rescue, Ambulance, Tanker, Brush, Command, Utility):') || '',
+        station: prompt('Enter station (optional):') || '',
+        seats: parseInt(prompt('Enter number of seats:') || '0'),
+        pump_gpm: parseInt(prompt('Enter pump capacity in GPM (0 if none):') || '0'),
+        sort_order: parseInt(prompt('Enter sort order (number):') || '99')
+    };
+    
+    window.go.main.App.CreateApparatus(unit)
+        .then(() => {
+            alert('Apparatus created successfully!');
+            loadPicklistItems();
+        })
+        .catch(error => {
+            alert('Failed to create apparatus: ' + error);
+        });
+}
+
 function showAddPicklist() {
     const category = document.getElementById('picklist-category').value;
     if (!category) {
         alert('Please select a category first');
         return;
     }
+    if (category === 'apparatus') {
+        showAddApparatus();
+        return;
+    }
     
     const value = prompt('Enter new item value:');
     if (!value) return;
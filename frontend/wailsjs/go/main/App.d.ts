@@ -2,60 +2,262 @@
 // This file is automatically generated. DO NOT EDIT
 import {db} from '../models';
 
+export function AddCallInventoryUsage(arg1:db.InventoryUsage):Promise<void>;
+
+export function AddCallParty(arg1:db.Party):Promise<void>;
+
+export function AddCallPatient(arg1:db.Patient):Promise<void>;
+
+export function AddMVAVehicle(arg1:db.MVAVehicle):Promise<void>;
+
+export function BulkAddApparatus(arg1:db.CallFilter,arg2:number,arg3:boolean):Promise<db.BulkResult>;
+
+export function BulkAddResponder(arg1:db.CallFilter,arg2:number,arg3:string,arg4:boolean):Promise<db.BulkResult>;
+
+export function BulkReassignCreatedBy(arg1:db.CallFilter,arg2:number,arg3:boolean):Promise<db.BulkResult>;
+
+export function BulkRemoveApparatus(arg1:db.CallFilter,arg2:number,arg3:boolean):Promise<db.BulkResult>;
+
+export function BulkRemoveResponder(arg1:db.CallFilter,arg2:number,arg3:boolean):Promise<db.BulkResult>;
+
+export function BulkSetCallField(arg1:db.CallFilter,arg2:string,arg3:string,arg4:boolean):Promise<db.BulkResult>;
+
 export function ChangePIN(arg1:string,arg2:string):Promise<void>;
 
 export function ChangeUserPIN(arg1:number,arg2:string):Promise<void>;
 
-export function CreateCall(arg1:db.Call,arg2:Array<number>,arg3:Array<number>,arg4:Array<string>):Promise<void>;
+export function CheckDuplicateCalls(arg1:db.Call):Promise<Array<db.Call>>;
+
+export function CreateApparatus(arg1:db.Apparatus):Promise<void>;
+
+export function CreateCall(arg1:db.Call,arg2:Array<number>,arg3:Array<number>,arg4:Array<string>,arg5:Array<number>):Promise<db.DuplicateWarning>;
+
+export function CreateCustomField(arg1:db.CustomField):Promise<void>;
+
+export function CreateEvent(arg1:db.Event,arg2:Array<number>):Promise<void>;
+
+export function CreateInventoryItem(arg1:db.InventoryItem):Promise<void>;
+
+export function CreateNarrativeTemplate(arg1:db.NarrativeTemplate):Promise<void>;
 
 export function CreatePicklist(arg1:string,arg2:string,arg3:number):Promise<void>;
 
+export function CreateSavedFilter(arg1:string,arg2:db.CallFilter,arg3:boolean):Promise<db.SavedFilter>;
+
 export function CreateUser(arg1:string,arg2:string,arg3:string,arg4:string,arg5:string,arg6:boolean):Promise<void>;
 
+export function DeleteApparatus(arg1:number):Promise<void>;
+
+export function DeleteAttachment(arg1:number):Promise<void>;
+
 export function DeleteCall(arg1:number):Promise<void>;
 
+export function DeleteCallInventoryUsage(arg1:number):Promise<void>;
+
+export function DeleteCallParty(arg1:number):Promise<void>;
+
+export function DeleteCallPatient(arg1:number):Promise<void>;
+
+export function DeleteCustomField(arg1:number):Promise<void>;
+
+export function DeleteEvent(arg1:number):Promise<void>;
+
+export function DeleteInventoryItem(arg1:number):Promise<void>;
+
 export function DeleteLogo():Promise<void>;
 
+export function DeleteMVAVehicle(arg1:number):Promise<void>;
+
+export function DeleteNarrativeTemplate(arg1:number):Promise<void>;
+
 export function DeletePicklist(arg1:number):Promise<void>;
 
+export function DeleteSavedFilter(arg1:number):Promise<void>;
+
 export function DeleteUser(arg1:number):Promise<void>;
 
+export function DownloadAttachment(arg1:number):Promise<db.Attachment>;
+
+export function ExportCallLogPDF(arg1:db.CallFilter,arg2:string):Promise<void>;
+
+export function ExportCallPDF(arg1:number,arg2:string):Promise<void>;
+
+export function ExportCallsCSV(arg1:db.CallFilter,arg2:string):Promise<void>;
+
+export function ExportEventRosterPDF(arg1:number,arg2:string):Promise<void>;
+
+export function ExportSavedFilterCSV(arg1:number,arg2:string):Promise<void>;
+
+export function ExportSavedFilterPDF(arg1:number,arg2:string):Promise<void>;
+
+export function ExportSummaryPDF(arg1:string,arg2:string,arg3:string):Promise<void>;
+
+export function FilterCalls(arg1:db.CallFilter,arg2:string,arg3:number):Promise<db.CallPage>;
+
+export function FilterEvents(arg1:db.EventFilter,arg2:string,arg3:number):Promise<db.EventPage>;
+
+export function GetActiveApparatus():Promise<Array<db.Apparatus>>;
+
 export function GetActiveUsers():Promise<Array<db.User>>;
 
 export function GetAdminUsers():Promise<Array<db.User>>;
 
+export function GetAllCustomFields():Promise<Array<db.CustomField>>;
+
+export function GetAllInventoryItems():Promise<Array<db.InventoryItem>>;
+
+export function GetAllNarrativeTemplates():Promise<Array<db.NarrativeTemplate>>;
+
 export function GetAllUsers():Promise<Array<db.User>>;
 
+export function GetApparatus():Promise<Array<db.Apparatus>>;
+
+export function GetApparatusUsageHistory(arg1:number,arg2:string,arg3:number):Promise<db.ApparatusUsagePage>;
+
+export function GetAuditLog(arg1:string,arg2:number):Promise<db.AuditLogPage>;
+
+export function GetCallApparatusUsage(arg1:number):Promise<Array<db.ApparatusUsage>>;
+
+export function GetCallAttachments(arg1:number):Promise<Array<db.Attachment>>;
+
 export function GetCallByID(arg1:number):Promise<db.Call>;
 
+export function GetCallInventoryUsage(arg1:number):Promise<Array<db.InventoryUsage>>;
+
+export function GetCallLinkTypes():Promise<Array<string>>;
+
+export function GetCallLinks(arg1:number):Promise<Array<db.CallLink>>;
+
+export function GetCallParties(arg1:number):Promise<Array<db.Party>>;
+
+export function GetCallPatients(arg1:number):Promise<Array<db.Patient>>;
+
+export function GetCallStats(arg1:string,arg2:string,arg3:db.CallFilter):Promise<db.CallStats>;
+
 export function GetCallYears():Promise<Array<number>>;
 
-export function GetCallsByYear(arg1:number):Promise<Array<db.Call>>;
+export function GetCallsByYear(arg1:number,arg2:string,arg3:number):Promise<db.CallPage>;
 
 export function GetCurrentUser():Promise<db.User>;
 
+export function GetCustomFields():Promise<Array<db.CustomField>>;
+
+export function GetDefaultSavedFilter():Promise<db.SavedFilter>;
+
+export function GetDuplicateCallReport():Promise<Array<db.DuplicatePair>>;
+
+export function GetEventByID(arg1:number):Promise<db.Event>;
+
+export function GetFireDetails(arg1:number):Promise<db.FireDetails>;
+
+export function GetFireLossTotals(arg1:string,arg2:string):Promise<db.FireLossTotals>;
+
+export function GetFormFields():Promise<Array<db.FormField>>;
+
+export function GetIncidentTypeCodes():Promise<Array<db.IncidentTypeCode>>;
+
+export function GetInventoryItems():Promise<Array<db.InventoryItem>>;
+
+export function GetInventoryUsageReport(arg1:string,arg2:string):Promise<Array<db.InventoryUsageTotal>>;
+
 export function GetLogo():Promise<db.Logo>;
 
+export function GetMVAVehicles(arg1:number):Promise<Array<db.MVAVehicle>>;
+
+export function GetMVAsByLocation(arg1:string,arg2:string,arg3:number,arg4:number,arg5:string,arg6:string):Promise<db.MVALocationReport>;
+
+export function GetMaintenanceDueReport():Promise<Array<db.MaintenanceDue>>;
+
+export function GetNarrativeTemplates(arg1:string):Promise<Array<db.NarrativeTemplate>>;
+
 export function GetNextCallNumber(arg1:number):Promise<string>;
 
+export function GetNextEventNumber(arg1:number):Promise<string>;
+
+export function GetPartyPIIFields():Promise<Array<string>>;
+
 export function GetPicklistByCategory(arg1:string):Promise<Array<db.Picklist>>;
 
-export function GetRecentCalls(arg1:number):Promise<Array<db.Call>>;
+export function GetPremiseHistory(arg1:string,arg2:string,arg3:string,arg4:number):Promise<db.PremiseHistory>;
+
+export function GetRecentCalls(arg1:string,arg2:number):Promise<db.CallPage>;
+
+export function GetRecentEvents(arg1:string,arg2:number):Promise<db.EventPage>;
+
+export function GetSavedFilters():Promise<Array<db.SavedFilter>>;
+
+export function GetSettings():Promise<Array<db.Setting>>;
 
 export function GetUserByID(arg1:number):Promise<db.User>;
 
 export function GetVersion():Promise<Record<string, string>>;
 
+export function LinkCalls(arg1:number,arg2:number,arg3:string,arg4:string):Promise<db.CallLink>;
+
 export function Login(arg1:string,arg2:string):Promise<db.User>;
 
 export function Logout():Promise<void>;
 
-export function SearchCalls(arg1:string):Promise<Array<db.Call>>;
+export function LookupIncidentTypeCode(arg1:string):Promise<db.IncidentTypeCode>;
+
+export function RecordApparatusService(arg1:db.ApparatusService):Promise<void>;
+
+export function RenderNarrativeTemplate(arg1:number,arg2:db.Call,arg3:Array<number>,arg4:Array<number>):Promise<string>;
+
+export function RunSavedFilter(arg1:number,arg2:string,arg3:number):Promise<db.CallPage>;
+
+export function SaveApparatusUsage(arg1:db.ApparatusUsage):Promise<void>;
+
+export function SaveFireDetails(arg1:db.FireDetails):Promise<void>;
+
+export function SearchCallMatches(arg1:db.CallFilter,arg2:string,arg3:number):Promise<db.CallMatchPage>;
+
+export function SearchCallText(arg1:string,arg2:string,arg3:number):Promise<db.CallSearchPage>;
+
+export function SearchCalls(arg1:string,arg2:string,arg3:number):Promise<db.CallPage>;
+
+export function SearchEvents(arg1:string,arg2:string,arg3:number):Promise<db.EventPage>;
+
+export function SetApparatusInService(arg1:number,arg2:boolean):Promise<void>;
+
+export function SetDefaultSavedFilter(arg1:number):Promise<void>;
+
+export function SetPicklistCode(arg1:number,arg2:string,arg3:string):Promise<void>;
+
+export function SuggestAddresses(arg1:string):Promise<Array<db.AddressSuggestion>>;
+
+export function SuggestResponders(arg1:string):Promise<Array<db.User>>;
+
+export function UnlinkCalls(arg1:number):Promise<void>;
+
+export function UpdateApparatus(arg1:db.Apparatus):Promise<void>;
 
 export function UpdateCall(arg1:db.Call,arg2:Array<number>,arg3:Array<number>,arg4:Array<string>):Promise<void>;
 
+export function UpdateCallInventoryUsage(arg1:db.InventoryUsage):Promise<void>;
+
+export function UpdateCallParty(arg1:db.Party):Promise<void>;
+
+export function UpdateCallPatient(arg1:db.Patient):Promise<void>;
+
+export function UpdateCustomField(arg1:db.CustomField):Promise<void>;
+
+export function UpdateEvent(arg1:db.Event,arg2:Array<number>):Promise<void>;
+
+export function UpdateFormField(arg1:db.FormField):Promise<void>;
+
+export function UpdateInventoryItem(arg1:db.InventoryItem):Promise<void>;
+
+export function UpdateMVAVehicle(arg1:db.MVAVehicle):Promise<void>;
+
+export function UpdateNarrativeTemplate(arg1:db.NarrativeTemplate):Promise<void>;
+
 export function UpdatePicklist(arg1:db.Picklist):Promise<void>;
 
+export function UpdateSavedFilter(arg1:db.SavedFilter):Promise<void>;
+
+export function UpdateSetting(arg1:string,arg2:string):Promise<void>;
+
 export function UpdateUser(arg1:db.User):Promise<void>;
 
 export function UpdateUserAdminStatus(arg1:number,arg2:boolean):Promise<void>;
@@ -64,4 +266,6 @@ export function UpdateUserJoinDate(arg1:number,arg2:string):Promise<void>;
 
 export function UpdateUserPosition(arg1:number,arg2:string):Promise<void>;
 
+export function UploadAttachment(arg1:number,arg2:string,arg3:Array<number>,arg4:string,arg5:string):Promise<db.Attachment>;
+
 export function UploadLogo(arg1:Array<number>,arg2:string):Promise<void>;
@@ -2,6 +2,46 @@
 // Cynhyrchwyd y ffeil hon yn awtomatig. PEIDIWCH Â MODIWL
 // This file is automatically generated. DO NOT EDIT
 
+export function AddCallInventoryUsage(arg1) {
+  return window['go']['main']['App']['AddCallInventoryUsage'](arg1);
+}
+
+export function AddCallParty(arg1) {
+  return window['go']['main']['App']['AddCallParty'](arg1);
+}
+
+export function AddCallPatient(arg1) {
+  return window['go']['main']['App']['AddCallPatient'](arg1);
+}
+
+export function AddMVAVehicle(arg1) {
+  return window['go']['main']['App']['AddMVAVehicle'](arg1);
+}
+
+export function BulkAddApparatus(arg1, arg2, arg3) {
+  return window['go']['main']['App']['BulkAddApparatus'](arg1, arg2, arg3);
+}
+
+export function BulkAddResponder(arg1, arg2, arg3, arg4) {
+  return window['go']['main']['App']['BulkAddResponder'](arg1, arg2, arg3, arg4);
+}
+
+export function BulkReassignCreatedBy(arg1, arg2, arg3) {
+  return window['go']['main']['App']['BulkReassignCreatedBy'](arg1, arg2, arg3);
+}
+
+export function BulkRemoveApparatus(arg1, arg2, arg3) {
+  return window['go']['main']['App']['BulkRemoveApparatus'](arg1, arg2, arg3);
+}
+
+export function BulkRemoveResponder(arg1, arg2, arg3) {
+  return window['go']['main']['App']['BulkRemoveResponder'](arg1, arg2, arg3);
+}
+
+export function BulkSetCallField(arg1, arg2, arg3, arg4) {
+  return window['go']['main']['App']['BulkSetCallField'](arg1, arg2, arg3, arg4);
+}
+
 export function ChangePIN(arg1, arg2) {
   return window['go']['main']['App']['ChangePIN'](arg1, arg2);
 }
@@ -10,34 +50,150 @@ export function ChangeUserPIN(arg1, arg2) {
   return window['go']['main']['App']['ChangeUserPIN'](arg1, arg2);
 }
 
-export function CreateCall(arg1, arg2, arg3, arg4) {
-  return window['go']['main']['App']['CreateCall'](arg1, arg2, arg3, arg4);
+export function CheckDuplicateCalls(arg1) {
+  return window['go']['main']['App']['CheckDuplicateCalls'](arg1);
+}
+
+export function CreateApparatus(arg1) {
+  return window['go']['main']['App']['CreateApparatus'](arg1);
+}
+
+export function CreateCall(arg1, arg2, arg3, arg4, arg5) {
+  return window['go']['main']['App']['CreateCall'](arg1, arg2, arg3, arg4, arg5);
+}
+
+export function CreateCustomField(arg1) {
+  return window['go']['main']['App']['CreateCustomField'](arg1);
+}
+
+export function CreateEvent(arg1, arg2) {
+  return window['go']['main']['App']['CreateEvent'](arg1, arg2);
+}
+
+export function CreateInventoryItem(arg1) {
+  return window['go']['main']['App']['CreateInventoryItem'](arg1);
+}
+
+export function CreateNarrativeTemplate(arg1) {
+  return window['go']['main']['App']['CreateNarrativeTemplate'](arg1);
 }
 
 export function CreatePicklist(arg1, arg2, arg3) {
   return window['go']['main']['App']['CreatePicklist'](arg1, arg2, arg3);
 }
 
+export function CreateSavedFilter(arg1, arg2, arg3) {
+  return window['go']['main']['App']['CreateSavedFilter'](arg1, arg2, arg3);
+}
+
 export function CreateUser(arg1, arg2, arg3, arg4, arg5, arg6) {
   return window['go']['main']['App']['CreateUser'](arg1, arg2, arg3, arg4, arg5, arg6);
 }
 
+export function DeleteApparatus(arg1) {
+  return window['go']['main']['App']['DeleteApparatus'](arg1);
+}
+
+export function DeleteAttachment(arg1) {
+  return window['go']['main']['App']['DeleteAttachment'](arg1);
+}
+
 export function DeleteCall(arg1) {
   return window['go']['main']['App']['DeleteCall'](arg1);
 }
 
+export function DeleteCallInventoryUsage(arg1) {
+  return window['go']['main']['App']['DeleteCallInventoryUsage'](arg1);
+}
+
+export function DeleteCallParty(arg1) {
+  return window['go']['main']['App']['DeleteCallParty'](arg1);
+}
+
+export function DeleteCallPatient(arg1) {
+  return window['go']['main']['App']['DeleteCallPatient'](arg1);
+}
+
+export function DeleteCustomField(arg1) {
+  return window['go']['main']['App']['DeleteCustomField'](arg1);
+}
+
+export function DeleteEvent(arg1) {
+  return window['go']['main']['App']['DeleteEvent'](arg1);
+}
+
+export function DeleteInventoryItem(arg1) {
+  return window['go']['main']['App']['DeleteInventoryItem'](arg1);
+}
+
 export function DeleteLogo() {
   return window['go']['main']['App']['DeleteLogo']();
 }
 
+export function DeleteMVAVehicle(arg1) {
+  return window['go']['main']['App']['DeleteMVAVehicle'](arg1);
+}
+
+export function DeleteNarrativeTemplate(arg1) {
+  return window['go']['main']['App']['DeleteNarrativeTemplate'](arg1);
+}
+
 export function DeletePicklist(arg1) {
   return window['go']['main']['App']['DeletePicklist'](arg1);
 }
 
+export function DeleteSavedFilter(arg1) {
+  return window['go']['main']['App']['DeleteSavedFilter'](arg1);
+}
+
 export function DeleteUser(arg1) {
   return window['go']['main']['App']['DeleteUser'](arg1);
 }
 
+export function DownloadAttachment(arg1) {
+  return window['go']['main']['App']['DownloadAttachment'](arg1);
+}
+
+export function ExportCallLogPDF(arg1, arg2) {
+  return window['go']['main']['App']['ExportCallLogPDF'](arg1, arg2);
+}
+
+export function ExportCallPDF(arg1, arg2) {
+  return window['go']['main']['App']['ExportCallPDF'](arg1, arg2);
+}
+
+export function ExportCallsCSV(arg1, arg2) {
+  return window['go']['main']['App']['ExportCallsCSV'](arg1, arg2);
+}
+
+export function ExportEventRosterPDF(arg1, arg2) {
+  return window['go']['main']['App']['ExportEventRosterPDF'](arg1, arg2);
+}
+
+export function ExportSavedFilterCSV(arg1, arg2) {
+  return window['go']['main']['App']['ExportSavedFilterCSV'](arg1, arg2);
+}
+
+export function ExportSavedFilterPDF(arg1, arg2) {
+  return window['go']['main']['App']['ExportSavedFilterPDF'](arg1, arg2);
+}
+
+export function ExportSummaryPDF(arg1, arg2, arg3) {
+  return window['go']['main']['App']['ExportSummaryPDF'](arg1, arg2, arg3);
+}
+
+export function FilterCalls(arg1, arg2, arg3) {
+  return window['go']['main']['App']['FilterCalls'](arg1, arg2, arg3);
+}
+
+export function FilterEvents(arg1, arg2, arg3) {
+  return window['go']['main']['App']['FilterEvents'](arg1, arg2, arg3);
+}
+
+export function GetActiveApparatus() {
+  return window['go']['main']['App']['GetActiveApparatus']();
+}
+
 export function GetActiveUsers() {
   return window['go']['main']['App']['GetActiveUsers']();
 }
@@ -46,40 +202,176 @@ export function GetAdminUsers() {
   return window['go']['main']['App']['GetAdminUsers']();
 }
 
+export function GetAllCustomFields() {
+  return window['go']['main']['App']['GetAllCustomFields']();
+}
+
+export function GetAllInventoryItems() {
+  return window['go']['main']['App']['GetAllInventoryItems']();
+}
+
+export function GetAllNarrativeTemplates() {
+  return window['go']['main']['App']['GetAllNarrativeTemplates']();
+}
+
 export function GetAllUsers() {
   return window['go']['main']['App']['GetAllUsers']();
 }
 
+export function GetApparatus() {
+  return window['go']['main']['App']['GetApparatus']();
+}
+
+export function GetApparatusUsageHistory(arg1, arg2, arg3) {
+  return window['go']['main']['App']['GetApparatusUsageHistory'](arg1, arg2, arg3);
+}
+
+export function GetAuditLog(arg1, arg2) {
+  return window['go']['main']['App']['GetAuditLog'](arg1, arg2);
+}
+
+export function GetCallApparatusUsage(arg1) {
+  return window['go']['main']['App']['GetCallApparatusUsage'](arg1);
+}
+
+export function GetCallAttachments(arg1) {
+  return window['go']['main']['App']['GetCallAttachments'](arg1);
+}
+
 export function GetCallByID(arg1) {
   return window['go']['main']['App']['GetCallByID'](arg1);
 }
 
+export function GetCallInventoryUsage(arg1) {
+  return window['go']['main']['App']['GetCallInventoryUsage'](arg1);
+}
+
+export function GetCallLinkTypes() {
+  return window['go']['main']['App']['GetCallLinkTypes']();
+}
+
+export function GetCallLinks(arg1) {
+  return window['go']['main']['App']['GetCallLinks'](arg1);
+}
+
+export function GetCallParties(arg1) {
+  return window['go']['main']['App']['GetCallParties'](arg1);
+}
+
+export function GetCallPatients(arg1) {
+  return window['go']['main']['App']['GetCallPatients'](arg1);
+}
+
+export function GetCallStats(arg1, arg2, arg3) {
+  return window['go']['main']['App']['GetCallStats'](arg1, arg2, arg3);
+}
+
 export function GetCallYears() {
   return window['go']['main']['App']['GetCallYears']();
 }
 
-export function GetCallsByYear(arg1) {
-  return window['go']['main']['App']['GetCallsByYear'](arg1);
+export function GetCallsByYear(arg1, arg2, arg3) {
+  return window['go']['main']['App']['GetCallsByYear'](arg1, arg2, arg3);
 }
 
 export function GetCurrentUser() {
   return window['go']['main']['App']['GetCurrentUser']();
 }
 
+export function GetCustomFields() {
+  return window['go']['main']['App']['GetCustomFields']();
+}
+
+export function GetDefaultSavedFilter() {
+  return window['go']['main']['App']['GetDefaultSavedFilter']();
+}
+
+export function GetDuplicateCallReport() {
+  return window['go']['main']['App']['GetDuplicateCallReport']();
+}
+
+export function GetEventByID(arg1) {
+  return window['go']['main']['App']['GetEventByID'](arg1);
+}
+
+export function GetFireDetails(arg1) {
+  return window['go']['main']['App']['GetFireDetails'](arg1);
+}
+
+export function GetFireLossTotals(arg1, arg2) {
+  return window['go']['main']['App']['GetFireLossTotals'](arg1, arg2);
+}
+
+export function GetFormFields() {
+  return window['go']['main']['App']['GetFormFields']();
+}
+
+export function GetIncidentTypeCodes() {
+  return window['go']['main']['App']['GetIncidentTypeCodes']();
+}
+
+export function GetInventoryItems() {
+  return window['go']['main']['App']['GetInventoryItems']();
+}
+
+export function GetInventoryUsageReport(arg1, arg2) {
+  return window['go']['main']['App']['GetInventoryUsageReport'](arg1, arg2);
+}
+
 export function GetLogo() {
   return window['go']['main']['App']['GetLogo']();
 }
 
+export function GetMVAVehicles(arg1) {
+  return window['go']['main']['App']['GetMVAVehicles'](arg1);
+}
+
+export function GetMVAsByLocation(arg1, arg2, arg3, arg4, arg5, arg6) {
+  return window['go']['main']['App']['GetMVAsByLocation'](arg1, arg2, arg3, arg4, arg5, arg6);
+}
+
+export function GetMaintenanceDueReport() {
+  return window['go']['main']['App']['GetMaintenanceDueReport']();
+}
+
+export function GetNarrativeTemplates(arg1) {
+  return window['go']['main']['App']['GetNarrativeTemplates'](arg1);
+}
+
 export function GetNextCallNumber(arg1) {
   return window['go']['main']['App']['GetNextCallNumber'](arg1);
 }
 
+export function GetNextEventNumber(arg1) {
+  return window['go']['main']['App']['GetNextEventNumber'](arg1);
+}
+
+export function GetPartyPIIFields() {
+  return window['go']['main']['App']['GetPartyPIIFields']();
+}
+
 export function GetPicklistByCategory(arg1) {
   return window['go']['main']['App']['GetPicklistByCategory'](arg1);
 }
 
-export function GetRecentCalls(arg1) {
-  return window['go']['main']['App']['GetRecentCalls'](arg1);
+export function GetPremiseHistory(arg1, arg2, arg3, arg4) {
+  return window['go']['main']['App']['GetPremiseHistory'](arg1, arg2, arg3, arg4);
+}
+
+export function GetRecentCalls(arg1, arg2) {
+  return window['go']['main']['App']['GetRecentCalls'](arg1, arg2);
+}
+
+export function GetRecentEvents(arg1, arg2) {
+  return window['go']['main']['App']['GetRecentEvents'](arg1, arg2);
+}
+
+export function GetSavedFilters() {
+  return window['go']['main']['App']['GetSavedFilters']();
+}
+
+export function GetSettings() {
+  return window['go']['main']['App']['GetSettings']();
 }
 
 export function GetUserByID(arg1) {
@@ -90,6 +382,10 @@ export function GetVersion() {
   return window['go']['main']['App']['GetVersion']();
 }
 
+export function LinkCalls(arg1, arg2, arg3, arg4) {
+  return window['go']['main']['App']['LinkCalls'](arg1, arg2, arg3, arg4);
+}
+
 export function Login(arg1, arg2) {
   return window['go']['main']['App']['Login'](arg1, arg2);
 }
@@ -98,18 +394,126 @@ export function Logout() {
   return window['go']['main']['App']['Logout']();
 }
 
-export function SearchCalls(arg1) {
-  return window['go']['main']['App']['SearchCalls'](arg1);
+export function LookupIncidentTypeCode(arg1) {
+  return window['go']['main']['App']['LookupIncidentTypeCode'](arg1);
+}
+
+export function RecordApparatusService(arg1) {
+  return window['go']['main']['App']['RecordApparatusService'](arg1);
+}
+
+export function RenderNarrativeTemplate(arg1, arg2, arg3, arg4) {
+  return window['go']['main']['App']['RenderNarrativeTemplate'](arg1, arg2, arg3, arg4);
+}
+
+export function RunSavedFilter(arg1, arg2, arg3) {
+  return window['go']['main']['App']['RunSavedFilter'](arg1, arg2, arg3);
+}
+
+export function SaveApparatusUsage(arg1) {
+  return window['go']['main']['App']['SaveApparatusUsage'](arg1);
+}
+
+export function SaveFireDetails(arg1) {
+  return window['go']['main']['App']['SaveFireDetails'](arg1);
+}
+
+export function SearchCallMatches(arg1, arg2, arg3) {
+  return window['go']['main']['App']['SearchCallMatches'](arg1, arg2, arg3);
+}
+
+export function SearchCallText(arg1, arg2, arg3) {
+  return window['go']['main']['App']['SearchCallText'](arg1, arg2, arg3);
+}
+
+export function SearchCalls(arg1, arg2, arg3) {
+  return window['go']['main']['App']['SearchCalls'](arg1, arg2, arg3);
+}
+
+export function SearchEvents(arg1, arg2, arg3) {
+  return window['go']['main']['App']['SearchEvents'](arg1, arg2, arg3);
+}
+
+export function SetApparatusInService(arg1, arg2) {
+  return window['go']['main']['App']['SetApparatusInService'](arg1, arg2);
+}
+
+export function SetDefaultSavedFilter(arg1) {
+  return window['go']['main']['App']['SetDefaultSavedFilter'](arg1);
+}
+
+export function SetPicklistCode(arg1, arg2, arg3) {
+  return window['go']['main']['App']['SetPicklistCode'](arg1, arg2, arg3);
+}
+
+export function SuggestAddresses(arg1) {
+  return window['go']['main']['App']['SuggestAddresses'](arg1);
+}
+
+export function SuggestResponders(arg1) {
+  return window['go']['main']['App']['SuggestResponders'](arg1);
+}
+
+export function UnlinkCalls(arg1) {
+  return window['go']['main']['App']['UnlinkCalls'](arg1);
+}
+
+export function UpdateApparatus(arg1) {
+  return window['go']['main']['App']['UpdateApparatus'](arg1);
 }
 
 export function UpdateCall(arg1, arg2, arg3, arg4) {
   return window['go']['main']['App']['UpdateCall'](arg1, arg2, arg3, arg4);
 }
 
+export function UpdateCallInventoryUsage(arg1) {
+  return window['go']['main']['App']['UpdateCallInventoryUsage'](arg1);
+}
+
+export function UpdateCallParty(arg1) {
+  return window['go']['main']['App']['UpdateCallParty'](arg1);
+}
+
+export function UpdateCallPatient(arg1) {
+  return window['go']['main']['App']['UpdateCallPatient'](arg1);
+}
+
+export function UpdateCustomField(arg1) {
+  return window['go']['main']['App']['UpdateCustomField'](arg1);
+}
+
+export function UpdateEvent(arg1, arg2) {
+  return window['go']['main']['App']['UpdateEvent'](arg1, arg2);
+}
+
+export function UpdateFormField(arg1) {
+  return window['go']['main']['App']['UpdateFormField'](arg1);
+}
+
+export function UpdateInventoryItem(arg1) {
+  return window['go']['main']['App']['UpdateInventoryItem'](arg1);
+}
+
+export function UpdateMVAVehicle(arg1) {
+  return window['go']['main']['App']['UpdateMVAVehicle'](arg1);
+}
+
+export function UpdateNarrativeTemplate(arg1) {
+  return window['go']['main']['App']['UpdateNarrativeTemplate'](arg1);
+}
+
 export function UpdatePicklist(arg1) {
   return window['go']['main']['App']['UpdatePicklist'](arg1);
 }
 
+export function UpdateSavedFilter(arg1) {
+  return window['go']['main']['App']['UpdateSavedFilter'](arg1);
+}
+
+export function UpdateSetting(arg1, arg2) {
+  return window['go']['main']['App']['UpdateSetting'](arg1, arg2);
+}
+
 export function UpdateUser(arg1) {
   return window['go']['main']['App']['UpdateUser'](arg1);
 }
@@ -126,6 +530,10 @@ export function UpdateUserPosition(arg1, arg2) {
   return window['go']['main']['App']['UpdateUserPosition'](arg1, arg2);
 }
 
+export function UploadAttachment(arg1, arg2, arg3, arg4, arg5) {
+  return window['go']['main']['App']['UploadAttachment'](arg1, arg2, arg3, arg4, arg5);
+}
+
 export function UploadLogo(arg1, arg2) {
   return window['go']['main']['App']['UploadLogo'](arg1, arg2);
 }
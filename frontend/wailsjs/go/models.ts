@@ -1,49 +1,1535 @@
 export namespace db {
 	
+	export class AddressSuggestion {
+	    address: string;
+	    town: string;
+	    call_count: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new AddressSuggestion(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.address = source["address"];
+	        this.town = source["town"];
+	        this.call_count = source["call_count"];
+	    }
+	}
+	export class Apparatus {
+	    id: number;
+	    name: string;
+	    unit_type: string;
+	    seats: number;
+	    pump_gpm: number;
+	    station: string;
+	    in_service: boolean;
+	    active: boolean;
+	    sort_order: number;
+	    // Go type: time
+	    created_at: any;
+	
+	    static createFrom(source: any = {}) {
+	        return new Apparatus(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.name = source["name"];
+	        this.unit_type = source["unit_type"];
+	        this.seats = source["seats"];
+	        this.pump_gpm = source["pump_gpm"];
+	        this.station = source["station"];
+	        this.in_service = source["in_service"];
+	        this.active = source["active"];
+	        this.sort_order = source["sort_order"];
+	        this.created_at = this.convertValues(source["created_at"], null);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class ApparatusService {
+	    id: number;
+	    apparatus_id: number;
+	    // Go type: time
+	    service_date: any;
+	    mileage?: number;
+	    engine_hours?: number;
+	    pump_hours?: number;
+	    notes: string;
+	    recorded_by: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new ApparatusService(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.apparatus_id = source["apparatus_id"];
+	        this.service_date = this.convertValues(source["service_date"], null);
+	        this.mileage = source["mileage"];
+	        this.engine_hours = source["engine_hours"];
+	        this.pump_hours = source["pump_hours"];
+	        this.notes = source["notes"];
+	        this.recorded_by = source["recorded_by"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class ApparatusUsage {
+	    id: number;
+	    call_id: number;
+	    apparatus_id: number;
+	    start_mileage?: number;
+	    end_mileage?: number;
+	    start_engine_hours?: number;
+	    end_engine_hours?: number;
+	    start_pump_hours?: number;
+	    end_pump_hours?: number;
+	    recorded_by: number;
+	    // Go type: time
+	    recorded_at: any;
+	    apparatus_name: string;
+	    incident_number: string;
+	    // Go type: time
+	    dispatched: any;
+	
+	    static createFrom(source: any = {}) {
+	        return new ApparatusUsage(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.call_id = source["call_id"];
+	        this.apparatus_id = source["apparatus_id"];
+	        this.start_mileage = source["start_mileage"];
+	        this.end_mileage = source["end_mileage"];
+	        this.start_engine_hours = source["start_engine_hours"];
+	        this.end_engine_hours = source["end_engine_hours"];
+	        this.start_pump_hours = source["start_pump_hours"];
+	        this.end_pump_hours = source["end_pump_hours"];
+	        this.recorded_by = source["recorded_by"];
+	        this.recorded_at = this.convertValues(source["recorded_at"], null);
+	        this.apparatus_name = source["apparatus_name"];
+	        this.incident_number = source["incident_number"];
+	        this.dispatched = this.convertValues(source["dispatched"], null);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class ApparatusUsagePage {
+	    items: ApparatusUsage[];
+	    total: number;
+	    next_cursor: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new ApparatusUsagePage(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.items = this.convertValues(source["items"], ApparatusUsage);
+	        this.total = source["total"];
+	        this.next_cursor = source["next_cursor"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class Attachment {
+	    id: number;
+	    call_id: number;
+	    file_name: string;
+	    mime_type: string;
+	    size: number;
+	    sha256: string;
+	    caption: string;
+	    uploaded_by: number;
+	    // Go type: time
+	    uploaded_at: any;
+	    data?: number[];
+	
+	    static createFrom(source: any = {}) {
+	        return new Attachment(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.call_id = source["call_id"];
+	        this.file_name = source["file_name"];
+	        this.mime_type = source["mime_type"];
+	        this.size = source["size"];
+	        this.sha256 = source["sha256"];
+	        this.caption = source["caption"];
+	        this.uploaded_by = source["uploaded_by"];
+	        this.uploaded_at = this.convertValues(source["uploaded_at"], null);
+	        this.data = source["data"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class AuditLog {
+	    id: number;
+	    user_id: number;
+	    action: string;
+	    table_name: string;
+	    record_id: number;
+	    changes: string;
+	    // Go type: time
+	    timestamp: any;
+	
+	    static createFrom(source: any = {}) {
+	        return new AuditLog(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.user_id = source["user_id"];
+	        this.action = source["action"];
+	        this.table_name = source["table_name"];
+	        this.record_id = source["record_id"];
+	        this.changes = source["changes"];
+	        this.timestamp = this.convertValues(source["timestamp"], null);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class AuditLogPage {
+	    items: AuditLog[];
+	    total: number;
+	    next_cursor: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new AuditLogPage(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.items = this.convertValues(source["items"], AuditLog);
+	        this.total = source["total"];
+	        this.next_cursor = source["next_cursor"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class CallLink {
+	    id: number;
+	    call_id: number;
+	    linked_call_id: number;
+	    link_type: string;
+	    notes: string;
+	    linked_incident_number: string;
+	    linked_call_type: string;
+	    // Go type: time
+	    linked_dispatched: any;
+	
+	    static createFrom(source: any = {}) {
+	        return new CallLink(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.call_id = source["call_id"];
+	        this.linked_call_id = source["linked_call_id"];
+	        this.link_type = source["link_type"];
+	        this.notes = source["notes"];
+	        this.linked_incident_number = source["linked_incident_number"];
+	        this.linked_call_type = source["linked_call_type"];
+	        this.linked_dispatched = this.convertValues(source["linked_dispatched"], null);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
 	export class Call {
 	    id: number;
-	    incident_number: string;
-	    call_type: string;
-	    mutual_aid: string;
-	    address: string;
-	    town: string;
-	    location_notes: string;
-	    // Go type: time
-	    dispatched: any;
-	    // Go type: time
-	    enroute?: any;
+	    incident_number: string;
+	    call_type: string;
+	    mutual_aid: string;
+	    address: string;
+	    town: string;
+	    location_notes: string;
+	    // Go type: time
+	    dispatched: any;
+	    // Go type: time
+	    enroute?: any;
+	    // Go type: time
+	    on_scene?: any;
+	    // Go type: time
+	    clear?: any;
+	    narrative: string;
+	    incident_type_code: string;
+	    address_key: string;
+	    temperature_f?: number;
+	    wind: string;
+	    precipitation: string;
+	    road_conditions: string;
+	    created_by: number;
+	    // Go type: time
+	    created_at: any;
+	    // Go type: time
+	    updated_at: any;
+	    links?: CallLink[];
+	    custom_fields?: Record<string, string>;
+	
+	    static createFrom(source: any = {}) {
+	        return new Call(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.incident_number = source["incident_number"];
+	        this.call_type = source["call_type"];
+	        this.mutual_aid = source["mutual_aid"];
+	        this.address = source["address"];
+	        this.town = source["town"];
+	        this.location_notes = source["location_notes"];
+	        this.dispatched = this.convertValues(source["dispatched"], null);
+	        this.enroute = this.convertValues(source["enroute"], null);
+	        this.on_scene = this.convertValues(source["on_scene"], null);
+	        this.clear = this.convertValues(source["clear"], null);
+	        this.narrative = source["narrative"];
+	        this.incident_type_code = source["incident_type_code"];
+	        this.address_key = source["address_key"];
+	        this.temperature_f = source["temperature_f"];
+	        this.wind = source["wind"];
+	        this.precipitation = source["precipitation"];
+	        this.road_conditions = source["road_conditions"];
+	        this.created_by = source["created_by"];
+	        this.created_at = this.convertValues(source["created_at"], null);
+	        this.updated_at = this.convertValues(source["updated_at"], null);
+	        this.links = this.convertValues(source["links"], CallLink);
+	        this.custom_fields = source["custom_fields"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class BulkResult {
+	    dry_run: boolean;
+	    affected: number;
+	    calls: Call[];
+	
+	    static createFrom(source: any = {}) {
+	        return new BulkResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.dry_run = source["dry_run"];
+	        this.affected = source["affected"];
+	        this.calls = this.convertValues(source["calls"], Call);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	export class CallFilter {
+	    text: string;
+	    // Go type: time
+	    dispatched_from?: any;
+	    // Go type: time
+	    dispatched_to?: any;
+	    call_types: string[];
+	    towns: string[];
+	    address_key: string;
+	    mutual_aid: string[];
+	    responder_ids: number[];
+	    all_responders: boolean;
+	    apparatus_ids: number[];
+	    all_apparatus: boolean;
+	    has_clear_time?: boolean;
+	    status: string;
+	    wind: string;
+	    precipitation: string;
+	    road_conditions: string;
+	    min_temperature_f?: number;
+	    max_temperature_f?: number;
+	    exclude: CallFilter[];
+	    sort: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new CallFilter(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.text = source["text"];
+	        this.dispatched_from = this.convertValues(source["dispatched_from"], null);
+	        this.dispatched_to = this.convertValues(source["dispatched_to"], null);
+	        this.call_types = source["call_types"];
+	        this.towns = source["towns"];
+	        this.address_key = source["address_key"];
+	        this.mutual_aid = source["mutual_aid"];
+	        this.responder_ids = source["responder_ids"];
+	        this.all_responders = source["all_responders"];
+	        this.apparatus_ids = source["apparatus_ids"];
+	        this.all_apparatus = source["all_apparatus"];
+	        this.has_clear_time = source["has_clear_time"];
+	        this.status = source["status"];
+	        this.wind = source["wind"];
+	        this.precipitation = source["precipitation"];
+	        this.road_conditions = source["road_conditions"];
+	        this.min_temperature_f = source["min_temperature_f"];
+	        this.max_temperature_f = source["max_temperature_f"];
+	        this.exclude = this.convertValues(source["exclude"], CallFilter);
+	        this.sort = source["sort"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	export class User {
+	    id: number;
+	    first_name: string;
+	    last_name: string;
+	    position: string;
+	    ems_level: string;
+	    is_admin: boolean;
+	    pin?: string;
+	    active: boolean;
+	    // Go type: time
+	    joined_date?: any;
+	    // Go type: time
+	    created: any;
+	
+	    static createFrom(source: any = {}) {
+	        return new User(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.first_name = source["first_name"];
+	        this.last_name = source["last_name"];
+	        this.position = source["position"];
+	        this.ems_level = source["ems_level"];
+	        this.is_admin = source["is_admin"];
+	        this.pin = source["pin"];
+	        this.active = source["active"];
+	        this.joined_date = this.convertValues(source["joined_date"], null);
+	        this.created = this.convertValues(source["created"], null);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class CallMatch {
+	    call: Call;
+	    matched_responders: User[];
+	    matched_apparatus: Apparatus[];
+	
+	    static createFrom(source: any = {}) {
+	        return new CallMatch(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.call = this.convertValues(source["call"], Call);
+	        this.matched_responders = this.convertValues(source["matched_responders"], User);
+	        this.matched_apparatus = this.convertValues(source["matched_apparatus"], Apparatus);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class CallMatchPage {
+	    items: CallMatch[];
+	    total: number;
+	    next_cursor: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new CallMatchPage(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.items = this.convertValues(source["items"], CallMatch);
+	        this.total = source["total"];
+	        this.next_cursor = source["next_cursor"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class CallPage {
+	    items: Call[];
+	    total: number;
+	    next_cursor: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new CallPage(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.items = this.convertValues(source["items"], Call);
+	        this.total = source["total"];
+	        this.next_cursor = source["next_cursor"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class CallSearchResult {
+	    call: Call;
+	    rank: number;
+	    snippet: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new CallSearchResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.call = this.convertValues(source["call"], Call);
+	        this.rank = source["rank"];
+	        this.snippet = source["snippet"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class CallSearchPage {
+	    items: CallSearchResult[];
+	    total: number;
+	    next_cursor: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new CallSearchPage(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.items = this.convertValues(source["items"], CallSearchResult);
+	        this.total = source["total"];
+	        this.next_cursor = source["next_cursor"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	export class StatBucket {
+	    key: string;
+	    previous_key?: string;
+	    current: number;
+	    previous: number;
+	    change: number;
+	    percent_change?: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new StatBucket(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.key = source["key"];
+	        this.previous_key = source["previous_key"];
+	        this.current = source["current"];
+	        this.previous = source["previous"];
+	        this.change = source["change"];
+	        this.percent_change = source["percent_change"];
+	    }
+	}
+	export class StatCount {
+	    current: number;
+	    previous: number;
+	    change: number;
+	    percent_change?: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new StatCount(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.current = source["current"];
+	        this.previous = source["previous"];
+	        this.change = source["change"];
+	        this.percent_change = source["percent_change"];
+	    }
+	}
+	export class CallStats {
+	    // Go type: time
+	    start: any;
+	    // Go type: time
+	    end: any;
+	    // Go type: time
+	    previous_start: any;
+	    // Go type: time
+	    previous_end: any;
+	    total: StatCount;
+	    mutual_aid_given: StatCount;
+	    mutual_aid_received: StatCount;
+	    by_call_type: StatBucket[];
+	    by_town: StatBucket[];
+	    by_month: StatBucket[];
+	    by_day_of_week: StatBucket[];
+	    by_hour: StatBucket[];
+	
+	    static createFrom(source: any = {}) {
+	        return new CallStats(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.start = this.convertValues(source["start"], null);
+	        this.end = this.convertValues(source["end"], null);
+	        this.previous_start = this.convertValues(source["previous_start"], null);
+	        this.previous_end = this.convertValues(source["previous_end"], null);
+	        this.total = this.convertValues(source["total"], StatCount);
+	        this.mutual_aid_given = this.convertValues(source["mutual_aid_given"], StatCount);
+	        this.mutual_aid_received = this.convertValues(source["mutual_aid_received"], StatCount);
+	        this.by_call_type = this.convertValues(source["by_call_type"], StatBucket);
+	        this.by_town = this.convertValues(source["by_town"], StatBucket);
+	        this.by_month = this.convertValues(source["by_month"], StatBucket);
+	        this.by_day_of_week = this.convertValues(source["by_day_of_week"], StatBucket);
+	        this.by_hour = this.convertValues(source["by_hour"], StatBucket);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class CallTypeCount {
+	    call_type: string;
+	    count: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new CallTypeCount(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.call_type = source["call_type"];
+	        this.count = source["count"];
+	    }
+	}
+	export class CustomField {
+	    id: number;
+	    field_name: string;
+	    label: string;
+	    field_type: string;
+	    picklist_category: string;
+	    required: boolean;
+	    active: boolean;
+	    sort_order: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new CustomField(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.field_name = source["field_name"];
+	        this.label = source["label"];
+	        this.field_type = source["field_type"];
+	        this.picklist_category = source["picklist_category"];
+	        this.required = source["required"];
+	        this.active = source["active"];
+	        this.sort_order = source["sort_order"];
+	    }
+	}
+	export class DuplicatePair {
+	    first: Call;
+	    second: Call;
+	    reason: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new DuplicatePair(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.first = this.convertValues(source["first"], Call);
+	        this.second = this.convertValues(source["second"], Call);
+	        this.reason = source["reason"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class DuplicateWarning {
+	    message: string;
+	    duplicates: Call[];
+	
+	    static createFrom(source: any = {}) {
+	        return new DuplicateWarning(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.message = source["message"];
+	        this.duplicates = this.convertValues(source["duplicates"], Call);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class Event {
+	    id: number;
+	    event_number: string;
+	    event_type: string;
+	    topic: string;
+	    instructor: string;
+	    location: string;
+	    // Go type: time
+	    start_time: any;
+	    hours: number;
+	    notes: string;
+	    created_by: number;
+	    // Go type: time
+	    created_at: any;
+	    // Go type: time
+	    updated_at: any;
+	    attendees?: User[];
+	
+	    static createFrom(source: any = {}) {
+	        return new Event(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.event_number = source["event_number"];
+	        this.event_type = source["event_type"];
+	        this.topic = source["topic"];
+	        this.instructor = source["instructor"];
+	        this.location = source["location"];
+	        this.start_time = this.convertValues(source["start_time"], null);
+	        this.hours = source["hours"];
+	        this.notes = source["notes"];
+	        this.created_by = source["created_by"];
+	        this.created_at = this.convertValues(source["created_at"], null);
+	        this.updated_at = this.convertValues(source["updated_at"], null);
+	        this.attendees = this.convertValues(source["attendees"], User);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class EventFilter {
+	    text: string;
+	    // Go type: time
+	    start_from?: any;
+	    // Go type: time
+	    start_to?: any;
+	    event_type: string;
+	    attendee_id: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new EventFilter(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.text = source["text"];
+	        this.start_from = this.convertValues(source["start_from"], null);
+	        this.start_to = this.convertValues(source["start_to"], null);
+	        this.event_type = source["event_type"];
+	        this.attendee_id = source["attendee_id"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class EventPage {
+	    items: Event[];
+	    total: number;
+	    next_cursor: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new EventPage(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.items = this.convertValues(source["items"], Event);
+	        this.total = source["total"];
+	        this.next_cursor = source["next_cursor"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class FireDetails {
+	    call_id: number;
+	    area_of_origin: string;
+	    heat_source: string;
+	    cause_category: string;
+	    property_loss: number;
+	    contents_loss: number;
+	    value_saved: number;
+	    detectors_present: string;
+	    notes: string;
+	    // Go type: time
+	    updated_at: any;
+	
+	    static createFrom(source: any = {}) {
+	        return new FireDetails(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.call_id = source["call_id"];
+	        this.area_of_origin = source["area_of_origin"];
+	        this.heat_source = source["heat_source"];
+	        this.cause_category = source["cause_category"];
+	        this.property_loss = source["property_loss"];
+	        this.contents_loss = source["contents_loss"];
+	        this.value_saved = source["value_saved"];
+	        this.detectors_present = source["detectors_present"];
+	        this.notes = source["notes"];
+	        this.updated_at = this.convertValues(source["updated_at"], null);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class FireLossTotals {
+	    fires: number;
+	    property_loss: number;
+	    contents_loss: number;
+	    value_saved: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new FireLossTotals(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.fires = source["fires"];
+	        this.property_loss = source["property_loss"];
+	        this.contents_loss = source["contents_loss"];
+	        this.value_saved = source["value_saved"];
+	    }
+	}
+	export class FormField {
+	    id: number;
+	    field_name: string;
+	    label: string;
+	    required: boolean;
+	    enabled: boolean;
+	    sort_order: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new FormField(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.field_name = source["field_name"];
+	        this.label = source["label"];
+	        this.required = source["required"];
+	        this.enabled = source["enabled"];
+	        this.sort_order = source["sort_order"];
+	    }
+	}
+	export class IncidentTypeCode {
+	    code: string;
+	    description: string;
+	    series: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new IncidentTypeCode(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.code = source["code"];
+	        this.description = source["description"];
+	        this.series = source["series"];
+	    }
+	}
+	export class InventoryItem {
+	    id: number;
+	    name: string;
+	    category: string;
+	    unit: string;
+	    unit_cost_cents: number;
+	    active: boolean;
+	    sort_order: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new InventoryItem(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.name = source["name"];
+	        this.category = source["category"];
+	        this.unit = source["unit"];
+	        this.unit_cost_cents = source["unit_cost_cents"];
+	        this.active = source["active"];
+	        this.sort_order = source["sort_order"];
+	    }
+	}
+	export class InventoryUsage {
+	    id: number;
+	    call_id: number;
+	    item_id: number;
+	    item_name: string;
+	    unit: string;
+	    quantity: number;
+	    notes: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new InventoryUsage(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.call_id = source["call_id"];
+	        this.item_id = source["item_id"];
+	        this.item_name = source["item_name"];
+	        this.unit = source["unit"];
+	        this.quantity = source["quantity"];
+	        this.notes = source["notes"];
+	    }
+	}
+	export class InventoryUsageTotal {
+	    item_id: number;
+	    item_name: string;
+	    category: string;
+	    unit: string;
+	    quantity: number;
+	    calls: number;
+	    total_cost_cents: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new InventoryUsageTotal(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.item_id = source["item_id"];
+	        this.item_name = source["item_name"];
+	        this.category = source["category"];
+	        this.unit = source["unit"];
+	        this.quantity = source["quantity"];
+	        this.calls = source["calls"];
+	        this.total_cost_cents = source["total_cost_cents"];
+	    }
+	}
+	export class Logo {
+	    id: number;
+	    image_data: number[];
+	    mime_type: string;
 	    // Go type: time
-	    on_scene?: any;
+	    uploaded_at: any;
+	    uploaded_by: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new Logo(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.image_data = source["image_data"];
+	        this.mime_type = source["mime_type"];
+	        this.uploaded_at = this.convertValues(source["uploaded_at"], null);
+	        this.uploaded_by = source["uploaded_by"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class MVAVehicle {
+	    id: number;
+	    call_id: number;
+	    year: number;
+	    make: string;
+	    model: string;
+	    plate: string;
+	    plate_state: string;
+	    damage: string;
+	    extrication: boolean;
+	    airbag_deployment: string;
+	    notes: string;
+	    party_ids: number[];
+	
+	    static createFrom(source: any = {}) {
+	        return new MVAVehicle(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.call_id = source["call_id"];
+	        this.year = source["year"];
+	        this.make = source["make"];
+	        this.model = source["model"];
+	        this.plate = source["plate"];
+	        this.plate_state = source["plate_state"];
+	        this.damage = source["damage"];
+	        this.extrication = source["extrication"];
+	        this.airbag_deployment = source["airbag_deployment"];
+	        this.notes = source["notes"];
+	        this.party_ids = source["party_ids"];
+	    }
+	}
+	export class MVAIncident {
+	    call: Call;
+	    vehicles: MVAVehicle[];
+	
+	    static createFrom(source: any = {}) {
+	        return new MVAIncident(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.call = this.convertValues(source["call"], Call);
+	        this.vehicles = this.convertValues(source["vehicles"], MVAVehicle);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class MVALocationReport {
+	    road: string;
+	    town: string;
+	    incidents: MVAIncident[];
+	    vehicles: number;
+	    extrications: number;
+	    airbag_deployments: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new MVALocationReport(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.road = source["road"];
+	        this.town = source["town"];
+	        this.incidents = this.convertValues(source["incidents"], MVAIncident);
+	        this.vehicles = source["vehicles"];
+	        this.extrications = source["extrications"];
+	        this.airbag_deployments = source["airbag_deployments"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	export class MaintenanceDue {
+	    apparatus: Apparatus;
 	    // Go type: time
-	    clear?: any;
-	    narrative: string;
-	    created_by: number;
+	    last_service_date?: any;
+	    current_mileage: number;
+	    miles_since_service: number;
+	    engine_hours_since_service: number;
+	    pump_hours_since_service: number;
+	    reasons: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new MaintenanceDue(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.apparatus = this.convertValues(source["apparatus"], Apparatus);
+	        this.last_service_date = this.convertValues(source["last_service_date"], null);
+	        this.current_mileage = source["current_mileage"];
+	        this.miles_since_service = source["miles_since_service"];
+	        this.engine_hours_since_service = source["engine_hours_since_service"];
+	        this.pump_hours_since_service = source["pump_hours_since_service"];
+	        this.reasons = source["reasons"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class NarrativeTemplate {
+	    id: number;
+	    call_type: string;
+	    name: string;
+	    body: string;
+	    sort_order: number;
+	    active: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new NarrativeTemplate(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.call_type = source["call_type"];
+	        this.name = source["name"];
+	        this.body = source["body"];
+	        this.sort_order = source["sort_order"];
+	        this.active = source["active"];
+	    }
+	}
+	export class Party {
+	    id: number;
+	    call_id: number;
+	    role: string;
+	    name: string;
+	    phone: string;
+	    address: string;
+	    vehicle_plate: string;
+	    vehicle_make: string;
+	    notes: string;
 	    // Go type: time
 	    created_at: any;
-	    // Go type: time
-	    updated_at: any;
 	
 	    static createFrom(source: any = {}) {
-	        return new Call(source);
+	        return new Party(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
 	        this.id = source["id"];
-	        this.incident_number = source["incident_number"];
-	        this.call_type = source["call_type"];
-	        this.mutual_aid = source["mutual_aid"];
+	        this.call_id = source["call_id"];
+	        this.role = source["role"];
+	        this.name = source["name"];
+	        this.phone = source["phone"];
 	        this.address = source["address"];
-	        this.town = source["town"];
-	        this.location_notes = source["location_notes"];
-	        this.dispatched = this.convertValues(source["dispatched"], null);
-	        this.enroute = this.convertValues(source["enroute"], null);
-	        this.on_scene = this.convertValues(source["on_scene"], null);
-	        this.clear = this.convertValues(source["clear"], null);
-	        this.narrative = source["narrative"];
-	        this.created_by = source["created_by"];
+	        this.vehicle_plate = source["vehicle_plate"];
+	        this.vehicle_make = source["vehicle_make"];
+	        this.notes = source["notes"];
 	        this.created_at = this.convertValues(source["created_at"], null);
-	        this.updated_at = this.convertValues(source["updated_at"], null);
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -64,25 +1550,33 @@ export namespace db {
 		    return a;
 		}
 	}
-	export class Logo {
+	export class Patient {
 	    id: number;
-	    image_data: number[];
-	    mime_type: string;
+	    call_id: number;
+	    age_range: string;
+	    chief_complaint: string;
+	    care_level: string;
+	    transport_disposition: string;
+	    destination_hospital: string;
+	    treating_responder_id?: number;
 	    // Go type: time
-	    uploaded_at: any;
-	    uploaded_by: number;
+	    created_at: any;
 	
 	    static createFrom(source: any = {}) {
-	        return new Logo(source);
+	        return new Patient(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
 	        this.id = source["id"];
-	        this.image_data = source["image_data"];
-	        this.mime_type = source["mime_type"];
-	        this.uploaded_at = this.convertValues(source["uploaded_at"], null);
-	        this.uploaded_by = source["uploaded_by"];
+	        this.call_id = source["call_id"];
+	        this.age_range = source["age_range"];
+	        this.chief_complaint = source["chief_complaint"];
+	        this.care_level = source["care_level"];
+	        this.transport_disposition = source["transport_disposition"];
+	        this.destination_hospital = source["destination_hospital"];
+	        this.treating_responder_id = source["treating_responder_id"];
+	        this.created_at = this.convertValues(source["created_at"], null);
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -109,6 +1603,8 @@ export namespace db {
 	    value: string;
 	    sort_order: number;
 	    active: boolean;
+	    code: string;
+	    code_description: string;
 	
 	    static createFrom(source: any = {}) {
 	        return new Picklist(source);
@@ -121,38 +1617,74 @@ export namespace db {
 	        this.value = source["value"];
 	        this.sort_order = source["sort_order"];
 	        this.active = source["active"];
+	        this.code = source["code"];
+	        this.code_description = source["code_description"];
 	    }
 	}
-	export class User {
+	export class PremiseHistory {
+	    address_key: string;
+	    town: string;
+	    calls: Call[];
+	    total: number;
+	    next_cursor: string;
+	    counts_by_type: CallTypeCount[];
+	
+	    static createFrom(source: any = {}) {
+	        return new PremiseHistory(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.address_key = source["address_key"];
+	        this.town = source["town"];
+	        this.calls = this.convertValues(source["calls"], Call);
+	        this.total = source["total"];
+	        this.next_cursor = source["next_cursor"];
+	        this.counts_by_type = this.convertValues(source["counts_by_type"], CallTypeCount);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class SavedFilter {
 	    id: number;
-	    first_name: string;
-	    last_name: string;
-	    position: string;
-	    ems_level: string;
-	    is_admin: boolean;
-	    pin?: string;
-	    active: boolean;
+	    name: string;
+	    owner_id: number;
+	    shared: boolean;
+	    filter: CallFilter;
 	    // Go type: time
-	    joined_date?: any;
+	    created_at: any;
 	    // Go type: time
-	    created: any;
+	    updated_at: any;
 	
 	    static createFrom(source: any = {}) {
-	        return new User(source);
+	        return new SavedFilter(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
 	        this.id = source["id"];
-	        this.first_name = source["first_name"];
-	        this.last_name = source["last_name"];
-	        this.position = source["position"];
-	        this.ems_level = source["ems_level"];
-	        this.is_admin = source["is_admin"];
-	        this.pin = source["pin"];
-	        this.active = source["active"];
-	        this.joined_date = this.convertValues(source["joined_date"], null);
-	        this.created = this.convertValues(source["created"], null);
+	        this.name = source["name"];
+	        this.owner_id = source["owner_id"];
+	        this.shared = source["shared"];
+	        this.filter = this.convertValues(source["filter"], CallFilter);
+	        this.created_at = this.convertValues(source["created_at"], null);
+	        this.updated_at = this.convertValues(source["updated_at"], null);
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -173,6 +1705,22 @@ export namespace db {
 		    return a;
 		}
 	}
+	export class Setting {
+	    key: string;
+	    value: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new Setting(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.key = source["key"];
+	        this.value = source["value"];
+	    }
+	}
+	
+	
 
 }
 
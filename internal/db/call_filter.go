@@ -0,0 +1,175 @@
+package db
+
+import (
+	"fmt"
+	"strings"
+)
+
+// callSortOrders maps CallSort values to ORDER BY clauses. Each ends on id
+// so calls with equal keys keep a stable order between pages.
+var callSortOrders = map[string]string{
+	"":                     "dispatched DESC, id DESC",
+	CallSortDispatchedDesc: "dispatched DESC, id DESC",
+	CallSortDispatchedAsc:  "dispatched ASC, id ASC",
+	CallSortIncidentNumber: "incident_number ASC, id ASC",
+	CallSortCallType:       "call_type ASC, dispatched DESC, id DESC",
+	CallSortTown:           "town ASC, dispatched DESC, id DESC",
+}
+
+// callStatusConditions maps CallStatus values to conditions on the timeline
+var callStatusConditions = map[string]string{
+	CallStatusDispatched: "enroute IS NULL AND on_scene IS NULL AND clear IS NULL",
+	CallStatusEnroute:    "enroute IS NOT NULL AND on_scene IS NULL AND clear IS NULL",
+	CallStatusOnScene:    "on_scene IS NOT NULL AND clear IS NULL",
+	CallStatusCleared:    "clear IS NOT NULL",
+}
+
+// where turns the filter into " AND ..." conditions on calls
+func (f CallFilter) where() (string, []interface{}, error) {
+	var conditions []string
+	var args []interface{}
+
+	if text := strings.TrimSpace(f.Text); text != "" {
+		// Incident numbers and addresses also match partial words, so
+		// "Mai" still finds "Main St" as it is typed
+		pattern := "%" + text + "%"
+		condition := "incident_number LIKE ? OR address LIKE ?"
+		args = append(args, pattern, pattern)
+		if match := ftsQuery(text); match != "" {
+			condition += " OR id IN (SELECT rowid FROM calls_fts WHERE calls_fts MATCH ?)"
+			args = append(args, match)
+		}
+		conditions = append(conditions, "("+condition+")")
+	}
+
+	if f.DispatchedFrom != nil {
+		conditions = append(conditions, "dispatched >= ?")
+		args = append(args, *f.DispatchedFrom)
+	}
+	if f.DispatchedTo != nil {
+		conditions = append(conditions, "dispatched <= ?")
+		args = append(args, *f.DispatchedTo)
+	}
+
+	for _, in := range []struct {
+		column string
+		values []string
+	}{
+		{"call_type", f.CallTypes},
+		{"town COLLATE NOCASE", f.Towns},
+		{"mutual_aid", f.MutualAid},
+	} {
+		var values []interface{}
+		for _, v := range in.values {
+			if v != "" {
+				values = append(values, v)
+			}
+		}
+		if len(values) > 0 {
+			conditions = append(conditions, in.column+" IN ("+placeholders(len(values))+")")
+			args = append(args, values...)
+		}
+	}
+
+	for _, eq := range []struct {
+		column string
+		value  string
+	}{
+		{"address_key", f.AddressKey},
+		{"weather_wind", f.Wind},
+		{"weather_precipitation", f.Precipitation},
+		{"road_conditions", f.RoadConditions},
+	} {
+		if eq.value != "" {
+			conditions = append(conditions, eq.column+" = ?")
+			args = append(args, eq.value)
+		}
+	}
+
+	for _, member := range []struct {
+		table  string
+		column string
+		ids    []int
+		all    bool
+	}{
+		{"call_responders", "responder_id", f.ResponderIDs, f.AllResponders},
+		{"call_apparatus", "apparatus_id", f.ApparatusIDs, f.AllApparatus},
+	} {
+		ids := uniqueIDs(member.ids)
+		if len(ids) == 0 {
+			continue
+		}
+		// table and column come from the list above, so they are safe to build into SQL
+		condition := fmt.Sprintf("id IN (SELECT call_id FROM %s WHERE %s IN (%s)", member.table, member.column, placeholders(len(ids)))
+		if member.all {
+			condition += fmt.Sprintf(" GROUP BY call_id HAVING COUNT(DISTINCT %s) = %d", member.column, len(ids))
+		}
+		conditions = append(conditions, condition+")")
+		args = append(args, idArgs(ids)...)
+	}
+
+	if f.HasClearTime != nil {
+		if *f.HasClearTime {
+			conditions = append(conditions, "clear IS NOT NULL")
+		} else {
+			conditions = append(conditions, "clear IS NULL")
+		}
+	}
+	if f.Status != "" {
+		condition, ok := callStatusConditions[f.Status]
+		if !ok {
+			return "", nil, fmt.Errorf("unknown call status %q", f.Status)
+		}
+		conditions = append(conditions, condition)
+	}
+
+	if f.MinTemperatureF != nil {
+		conditions = append(conditions, "weather_temp_f >= ?")
+		args = append(args, *f.MinTemperatureF)
+	}
+	if f.MaxTemperatureF != nil {
+		conditions = append(conditions, "weather_temp_f <= ?")
+		args = append(args, *f.MaxTemperatureF)
+	}
+
+	for _, exclude := range f.Exclude {
+		excludeWhere, excludeArgs, err := exclude.where()
+		if err != nil {
+			return "", nil, err
+		}
+		if excludeWhere == "" {
+			continue
+		}
+		// COALESCE keeps calls whose excluded column is NULL
+		conditions = append(conditions, "NOT COALESCE((1=1"+excludeWhere+"), 0)")
+		args = append(args, excludeArgs...)
+	}
+
+	var where string
+	for _, c := range conditions {
+		where += " AND " + c
+	}
+	return where, args, nil
+}
+
+// orderBy returns the ORDER BY clause for the filter's sort order
+func (f CallFilter) orderBy() (string, error) {
+	orderBy, ok := callSortOrders[f.Sort]
+	if !ok {
+		return "", fmt.Errorf("unknown sort order %q", f.Sort)
+	}
+	return orderBy, nil
+}
+
+// uniqueIDs returns ids without zeros or repeats, in their original order
+func uniqueIDs(ids []int) []int {
+	seen := make(map[int]bool)
+	var unique []int
+	for _, id := range ids {
+		if id != 0 && !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+	return unique
+}
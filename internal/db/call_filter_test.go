@@ -0,0 +1,96 @@
+package db
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSearchCallsByText(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	base := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	createTestCall(t, db, "Structure Fire", "45 Oak Ave", base)
+	createTestCall(t, db, "Rescue", "9 Elm St", base.Add(time.Hour))
+
+	calls, err := db.SearchCalls(CallFilter{Text: "oak"}, 100, 0)
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+	if len(calls) != 1 || calls[0].Address != "45 Oak Ave" {
+		t.Errorf("Expected only the Oak Ave call, got %+v", calls)
+	}
+
+	calls, err = db.SearchCalls(CallFilter{Text: "El"}, 100, 0)
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+	if len(calls) != 1 || calls[0].Address != "9 Elm St" {
+		t.Errorf("Expected a partial address to find the Elm St call, got %+v", calls)
+	}
+
+	calls, err = db.SearchCalls(CallFilter{Text: "no such place"}, 100, 0)
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+	if len(calls) != 0 {
+		t.Errorf("Expected no calls for unmatched text, got %d", len(calls))
+	}
+}
+
+func TestSearchCallsFilters(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	base := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	early := createTestCall(t, db, "Structure Fire", "1 Main St", base)
+	late := createTestCall(t, db, "Rescue", "2 Main St", base.Add(48*time.Hour))
+	alarm := createTestCall(t, db, "Alarm Activation", "3 Main St", base.Add(96*time.Hour))
+
+	clear := base.Add(time.Hour)
+	early.Clear = &clear
+	if err := db.UpdateCall(early, nil, nil, nil); err != nil {
+		t.Fatalf("Failed to clear call: %v", err)
+	}
+	if _, err := db.Exec("INSERT INTO call_responders (call_id, responder_id) VALUES (?, 1)", late.ID); err != nil {
+		t.Fatalf("Failed to add responder: %v", err)
+	}
+
+	from, to := base.Add(24*time.Hour), base.Add(72*time.Hour)
+	cleared, open := true, false
+	tests := []struct {
+		name   string
+		filter CallFilter
+		want   []int
+	}{
+		{"dispatch range", CallFilter{DispatchedFrom: &from, DispatchedTo: &to}, []int{late.ID}},
+		{"call types", CallFilter{CallTypes: []string{"Structure Fire", "Rescue"}, Sort: CallSortDispatchedAsc}, []int{early.ID, late.ID}},
+		{"responder", CallFilter{ResponderIDs: []int{1}}, []int{late.ID}},
+		{"cleared", CallFilter{HasClearTime: &cleared}, []int{early.ID}},
+		{"open status", CallFilter{HasClearTime: &open, Status: CallStatusDispatched, Towns: []string{"Stamford"}}, []int{alarm.ID, late.ID}},
+	}
+	for _, tt := range tests {
+		calls, err := db.SearchCalls(tt.filter, 100, 0)
+		if err != nil {
+			t.Fatalf("%s: search failed: %v", tt.name, err)
+		}
+		var got []int
+		for _, call := range calls {
+			got = append(got, call.ID)
+		}
+		if len(got) != len(tt.want) {
+			t.Errorf("%s: expected calls %v, got %v", tt.name, tt.want, got)
+			continue
+		}
+		for i := range got {
+			if got[i] != tt.want[i] {
+				t.Errorf("%s: expected calls %v, got %v", tt.name, tt.want, got)
+				break
+			}
+		}
+	}
+
+	if _, err := db.SearchCalls(CallFilter{Sort: "id; DROP TABLE calls"}, 100, 0); err == nil {
+		t.Error("Expected error for unknown sort order")
+	}
+}
@@ -0,0 +1,277 @@
+package db
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// QueryError is a syntax error in a search expression. Pos is the byte
+// offset of the offending term.
+type QueryError struct {
+	Pos int    `json:"pos"`
+	Msg string `json:"msg"`
+}
+
+func (e *QueryError) Error() string {
+	return fmt.Sprintf("column %d: %s", e.Pos+1, e.Msg)
+}
+
+// queryTerm is one term of a search expression: a bare word or "phrase",
+// or key:value, optionally negated with a leading -
+type queryTerm struct {
+	pos     int
+	negated bool
+	key     string
+	value   string
+	quoted  bool
+}
+
+// lexQuery splits a search expression into terms
+func lexQuery(query string) ([]queryTerm, error) {
+	var terms []queryTerm
+	i := 0
+	for i < len(query) {
+		if isQuerySpace(query[i]) {
+			i++
+			continue
+		}
+
+		term := queryTerm{pos: i}
+		if query[i] == '-' {
+			if i+1 == len(query) || isQuerySpace(query[i+1]) {
+				return nil, &QueryError{Pos: i, Msg: "- must be followed by a term"}
+			}
+			term.negated = true
+			i++
+		}
+
+		// A key is the run of letters before a colon, e.g. town: or type:
+		if j := strings.IndexByte(query[i:], ':'); j > 0 && isQueryKey(query[i:i+j]) {
+			term.key = strings.ToLower(query[i : i+j])
+			i += j + 1
+		}
+
+		start := i
+		if i < len(query) && query[i] == '"' {
+			end := strings.IndexByte(query[i+1:], '"')
+			if end < 0 {
+				return nil, &QueryError{Pos: i, Msg: "unclosed quote"}
+			}
+			term.value = query[i+1 : i+1+end]
+			term.quoted = true
+			i += end + 2
+		} else {
+			for i < len(query) && !isQuerySpace(query[i]) {
+				i++
+			}
+			term.value = query[start:i]
+		}
+
+		if term.key != "" && strings.TrimSpace(term.value) == "" {
+			return nil, &QueryError{Pos: term.pos, Msg: fmt.Sprintf("%s: needs a value", term.key)}
+		}
+		if term.key == "" && strings.TrimSpace(term.value) == "" {
+			return nil, &QueryError{Pos: term.pos, Msg: "empty phrase"}
+		}
+		terms = append(terms, term)
+	}
+	return terms, nil
+}
+
+func isQuerySpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n'
+}
+
+func isQueryKey(s string) bool {
+	for _, c := range s {
+		if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c == '_') {
+			return false
+		}
+	}
+	return true
+}
+
+// ParseCallQuery turns a search expression into a CallFilter. Terms are
+// combined with AND:
+//
+//	type:"Structure Fire"    call type (repeat for any of several)
+//	town:Stamford            town (repeat for any of several)
+//	after:2026-01-01         dispatched on or after a date
+//	before:2026-02-01        dispatched before a date
+//	on:2026-01-15            dispatched on a date
+//	responder:smith          a member responded (first/last name prefixes, e.g. "j smith")
+//	apparatus:"Engine 2"     a unit responded
+//	mutual                   mutual aid given or received (mutual:given, mutual:received, mutual:none)
+//	status:open              open, cleared, dispatched, enroute or on_scene
+//	sort:dispatched_asc      one of the CallSort values
+//	chimney "dunbar place"   full-text words and phrases; word* for a prefix
+//
+// A leading - excludes calls matching a term, e.g. -mutual or -town:Pownal.
+func (db *DB) ParseCallQuery(query string) (CallFilter, error) {
+	terms, err := lexQuery(query)
+	if err != nil {
+		return CallFilter{}, err
+	}
+
+	var filter CallFilter
+	var text []string
+	for _, term := range terms {
+		target := &filter
+		if term.negated {
+			filter.Exclude = append(filter.Exclude, CallFilter{})
+			target = &filter.Exclude[len(filter.Exclude)-1]
+		}
+
+		if term.key == "" && !term.quoted && strings.EqualFold(term.value, "mutual") {
+			target.MutualAid = []string{MutualAidGiven, MutualAidReceived}
+			continue
+		}
+		if term.key == "" {
+			word := term.value
+			if term.quoted {
+				word = `"` + word + `"`
+			}
+			if term.negated {
+				target.Text = word
+			} else {
+				text = append(text, word)
+			}
+			continue
+		}
+
+		if term.negated && term.key == "sort" {
+			return CallFilter{}, &QueryError{Pos: term.pos, Msg: "sort: cannot be excluded"}
+		}
+		if err := db.applyQueryTerm(target, term); err != nil {
+			return CallFilter{}, &QueryError{Pos: term.pos, Msg: err.Error()}
+		}
+	}
+	filter.Text = strings.Join(text, " ")
+	return filter, nil
+}
+
+// applyQueryTerm sets the filter field for one key:value term
+func (db *DB) applyQueryTerm(filter *CallFilter, term queryTerm) error {
+	value := strings.TrimSpace(term.value)
+	switch term.key {
+	case "type":
+		filter.CallTypes = append(filter.CallTypes, value)
+	case "town":
+		filter.Towns = append(filter.Towns, value)
+	case "after", "before", "on":
+		day, err := time.ParseInLocation("2006-01-02", value, time.Local)
+		if err != nil {
+			return fmt.Errorf("%s: expects a date like 2026-01-31", term.key)
+		}
+		endOfDay := day.AddDate(0, 0, 1).Add(-time.Nanosecond)
+		dayBefore := day.Add(-time.Nanosecond)
+		switch term.key {
+		case "after":
+			filter.DispatchedFrom = &day
+		case "before":
+			filter.DispatchedTo = &dayBefore
+		case "on":
+			filter.DispatchedFrom = &day
+			filter.DispatchedTo = &endOfDay
+		}
+	case "responder":
+		id, err := db.resolveResponder(value)
+		if err != nil {
+			return err
+		}
+		filter.ResponderIDs = append(filter.ResponderIDs, id)
+		filter.AllResponders = true
+	case "apparatus", "unit":
+		id, err := db.resolveApparatus(value)
+		if err != nil {
+			return err
+		}
+		filter.ApparatusIDs = append(filter.ApparatusIDs, id)
+		filter.AllApparatus = true
+	case "mutual":
+		switch strings.ToLower(value) {
+		case "yes":
+			filter.MutualAid = append(filter.MutualAid, MutualAidGiven, MutualAidReceived)
+		case "given":
+			filter.MutualAid = append(filter.MutualAid, MutualAidGiven)
+		case "received":
+			filter.MutualAid = append(filter.MutualAid, MutualAidReceived)
+		case "no", "none":
+			filter.MutualAid = append(filter.MutualAid, MutualAidNone)
+		default:
+			return fmt.Errorf("mutual: expects yes, given, received or none")
+		}
+	case "status":
+		status := strings.ToLower(value)
+		if status == "open" {
+			open := false
+			filter.HasClearTime = &open
+		} else if _, ok := callStatusConditions[status]; ok {
+			filter.Status = status
+		} else {
+			return fmt.Errorf("unknown status %q", value)
+		}
+	case "sort":
+		if _, ok := callSortOrders[strings.ToLower(value)]; !ok {
+			return fmt.Errorf("unknown sort order %q", value)
+		}
+		filter.Sort = strings.ToLower(value)
+	default:
+		return fmt.Errorf("unknown filter %q", term.key+":")
+	}
+	return nil
+}
+
+// resolveResponder finds the one member whose names match every word of
+// value as a prefix, so "smith", "j smith" and "J. Smith" all find Jo Smith
+func (db *DB) resolveResponder(value string) (int, error) {
+	words := strings.Fields(strings.ToLower(strings.ReplaceAll(value, ".", " ")))
+	users, err := db.GetAllUsers()
+	if err != nil {
+		return 0, err
+	}
+
+	var matches []User
+	for _, user := range users {
+		first, last := strings.ToLower(user.FirstName), strings.ToLower(user.LastName)
+		matched := true
+		for _, word := range words {
+			if !strings.HasPrefix(first, word) && !strings.HasPrefix(last, word) {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			matches = append(matches, user)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return 0, fmt.Errorf("no member matches responder %q", value)
+	case 1:
+		return matches[0].ID, nil
+	}
+	names := make([]string, len(matches))
+	for i, user := range matches {
+		names[i] = user.FirstName + " " + user.LastName
+	}
+	return 0, fmt.Errorf("responder %q matches %s; use more of the name", value, strings.Join(names, ", "))
+}
+
+// resolveApparatus finds a unit by name, ignoring case, spaces and dashes
+func (db *DB) resolveApparatus(value string) (int, error) {
+	normalize := strings.NewReplacer(" ", "", "-", "", ".", "")
+	want := strings.ToLower(normalize.Replace(value))
+	units, err := db.GetAllApparatus()
+	if err != nil {
+		return 0, err
+	}
+	for _, unit := range units {
+		if strings.ToLower(normalize.Replace(unit.Name)) == want {
+			return unit.ID, nil
+		}
+	}
+	return 0, fmt.Errorf("no apparatus named %q", value)
+}
@@ -0,0 +1,136 @@
+package db
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestParseCallQuery(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	if err := db.CreateUser("Jo", "Smith", "member", "EMT", "4321", false); err != nil {
+		t.Fatalf("Failed to create user: %v", err)
+	}
+
+	filter, err := db.ParseCallQuery(`type:"Structure Fire" town:Stamford after:2026-01-01 responder:smith -mutual chimney "dunbar place"`)
+	if err != nil {
+		t.Fatalf("Failed to parse query: %v", err)
+	}
+	if len(filter.CallTypes) != 1 || filter.CallTypes[0] != "Structure Fire" {
+		t.Errorf("Expected call type Structure Fire, got %v", filter.CallTypes)
+	}
+	if len(filter.Towns) != 1 || filter.Towns[0] != "Stamford" {
+		t.Errorf("Expected town Stamford, got %v", filter.Towns)
+	}
+	if filter.DispatchedFrom == nil || filter.DispatchedFrom.Format("2006-01-02") != "2026-01-01" {
+		t.Errorf("Expected dispatched from 2026-01-01, got %v", filter.DispatchedFrom)
+	}
+	if len(filter.ResponderIDs) != 1 {
+		t.Errorf("Expected one responder, got %v", filter.ResponderIDs)
+	}
+	if len(filter.Exclude) != 1 || len(filter.Exclude[0].MutualAid) != 2 {
+		t.Errorf("Expected mutual aid to be excluded, got %+v", filter.Exclude)
+	}
+	if filter.Text != `chimney "dunbar place"` {
+		t.Errorf("Expected free text to be kept for full-text search, got %q", filter.Text)
+	}
+}
+
+func TestParseCallQueryErrors(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	if err := db.CreateUser("Jo", "Smith", "member", "EMT", "4321", false); err != nil {
+		t.Fatalf("Failed to create user: %v", err)
+	}
+	if err := db.CreateUser("Dana", "Smith", "member", "EMT", "5678", false); err != nil {
+		t.Fatalf("Failed to create user: %v", err)
+	}
+
+	tests := []struct {
+		query string
+		pos   int
+	}{
+		{`town:Stamford type:"Structure Fire`, 19},
+		{`fire after:yesterday`, 5},
+		{`color:red`, 0},
+		{`town: fire`, 0},
+		{`fire - town:Stamford`, 5},
+		{`responder:smith`, 0},
+		{`apparatus:"Engine 9"`, 0},
+		{`-sort:town`, 0},
+	}
+	for _, tt := range tests {
+		_, err := db.ParseCallQuery(tt.query)
+		var queryErr *QueryError
+		if !errors.As(err, &queryErr) {
+			t.Errorf("%q: expected a QueryError, got %v", tt.query, err)
+			continue
+		}
+		if queryErr.Pos != tt.pos {
+			t.Errorf("%q: expected error at %d, got %d (%v)", tt.query, tt.pos, queryErr.Pos, err)
+		}
+	}
+
+	if _, err := db.ParseCallQuery(`responder:"j smith" unit:engine-2`); err != nil {
+		t.Errorf("Expected full name and loose unit name to resolve, got %v", err)
+	}
+}
+
+func TestExcludeKeepsNullColumns(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	base := time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+	cold := createTestCall(t, db, "Structure Fire", "1 Oak St", base)
+	freezing := 20
+	cold.TemperatureF = &freezing
+	cold.MutualAid = MutualAidGiven
+	if err := db.UpdateCall(cold, nil, nil, nil); err != nil {
+		t.Fatalf("Failed to update call: %v", err)
+	}
+	// No temperature was recorded on this call
+	unknown := createTestCall(t, db, "Structure Fire", "2 Oak St", base.Add(time.Hour))
+
+	maxTemp := 32
+	calls, err := db.SearchCalls(CallFilter{Exclude: []CallFilter{{MaxTemperatureF: &maxTemp}}}, 10, 0)
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+	if len(calls) != 1 || calls[0].ID != unknown.ID {
+		t.Errorf("Expected the call without a temperature to be kept, got %d calls", len(calls))
+	}
+
+	filter, err := db.ParseCallQuery(`type:"Structure Fire" -mutual`)
+	if err != nil {
+		t.Fatalf("Failed to parse query: %v", err)
+	}
+	calls, err = db.SearchCalls(filter, 10, 0)
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+	if len(calls) != 1 || calls[0].ID != unknown.ID {
+		t.Errorf("Expected only the call without mutual aid, got %d calls", len(calls))
+	}
+
+	// Bare mutual covers aid given and received
+	unknown.MutualAid = MutualAidReceived
+	if err := db.UpdateCall(unknown, nil, nil, nil); err != nil {
+		t.Fatalf("Failed to update call: %v", err)
+	}
+	for query, want := range map[string]int{"mutual": 2, "mutual:received": 1, "mutual:given": 1, "-mutual": 0} {
+		filter, err := db.ParseCallQuery(`type:"Structure Fire" ` + query)
+		if err != nil {
+			t.Fatalf("%s: failed to parse query: %v", query, err)
+		}
+		calls, err = db.SearchCalls(filter, 10, 0)
+		if err != nil {
+			t.Fatalf("%s: search failed: %v", query, err)
+		}
+		if len(calls) != want {
+			t.Errorf("%s: expected %d calls, got %d", query, want, len(calls))
+		}
+	}
+}
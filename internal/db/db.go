@@ -4,6 +4,7 @@ import (
 	"database/sql"
 	"fmt"
 	"log"
+	"strings"
 
 	_ "modernc.org/sqlite"
 )
@@ -43,6 +44,89 @@ func InitDB(dbPath string) (*DB, error) {
 		log.Printf("Warning: failed to seed default data: %v", err)
 	}
 
+	// Move apparatus out of picklists into their own table (migration for existing databases)
+	if err := database.ensureApparatusTable(); err != nil {
+		log.Printf("Warning: failed to migrate apparatus: %v", err)
+	}
+
+	// Drop readings of units no longer on their call (migration for existing databases)
+	if err := database.ensureUsageAssigned(); err != nil {
+		log.Printf("Warning: failed to remove unassigned apparatus usage: %v", err)
+	}
+
+	// Add incident type code columns and reference table (migration for existing databases)
+	if err := database.ensureIncidentTypeCodes(); err != nil {
+		log.Printf("Warning: failed to ensure incident type codes: %v", err)
+	}
+
+	// Add and backfill normalized address keys (migration for existing databases)
+	if err := database.ensureAddressKeys(); err != nil {
+		log.Printf("Warning: failed to ensure address keys: %v", err)
+	}
+
+	// Create and backfill the full-text search index (migration for existing databases)
+	if err := database.ensureCallSearchIndex(); err != nil {
+		log.Printf("Warning: failed to ensure call search index: %v", err)
+	}
+
+	// Add weather and road condition columns (migration for existing databases)
+	if err := database.ensureWeatherFields(); err != nil {
+		log.Printf("Warning: failed to ensure weather fields: %v", err)
+	}
+
+	// Seed settings added after the initial release
+	if err := database.ensureSetting("duplicate_window_minutes", "120"); err != nil {
+		log.Printf("Warning: failed to seed duplicate window setting: %v", err)
+	}
+	if err := database.ensureSetting("attachment_max_bytes", "10485760"); err != nil {
+		log.Printf("Warning: failed to seed attachment size setting: %v", err)
+	}
+	for key, value := range map[string]string{
+		"maintenance_interval_miles":        "5000",
+		"maintenance_interval_engine_hours": "250",
+		"maintenance_interval_pump_hours":   "50",
+	} {
+		if err := database.ensureSetting(key, value); err != nil {
+			log.Printf("Warning: failed to seed %s setting: %v", key, err)
+		}
+	}
+
+	// Seed picklist categories added after the initial release
+	if err := database.ensurePatientPicklists(); err != nil {
+		log.Printf("Warning: failed to seed patient picklists: %v", err)
+	}
+	if err := database.ensureFirePicklists(); err != nil {
+		log.Printf("Warning: failed to seed fire detail picklists: %v", err)
+	}
+	if err := database.ensurePicklistCategory("apparatus_type", apparatusTypes); err != nil {
+		log.Printf("Warning: failed to seed apparatus type picklist: %v", err)
+	}
+	if err := database.ensurePicklistCategory("party_role", []string{"Owner", "Occupant", "Driver", "Passenger", "Witness", "Patient Contact"}); err != nil {
+		log.Printf("Warning: failed to seed party role picklist: %v", err)
+	}
+	if err := database.ensureMVAPicklists(); err != nil {
+		log.Printf("Warning: failed to seed MVA picklists: %v", err)
+	}
+	if err := database.ensureInventoryItems(); err != nil {
+		log.Printf("Warning: failed to seed inventory items: %v", err)
+	}
+	if err := database.ensureMutualAidValues(); err != nil {
+		log.Printf("Warning: failed to migrate mutual aid values: %v", err)
+	}
+	if err := database.ensurePicklistCategory("event_type", []string{"Training", "Drill", "Meeting", "Detail"}); err != nil {
+		log.Printf("Warning: failed to seed event type picklist: %v", err)
+	}
+
+	// Add per-user default call list views (migration for existing databases)
+	if err := database.ensureDefaultSavedFilters(); err != nil {
+		log.Printf("Warning: failed to ensure default saved filters: %v", err)
+	}
+
+	// Move Training calls into events (migration for existing databases)
+	if err := database.ensureTrainingEvents(); err != nil {
+		log.Printf("Warning: failed to move training calls to events: %v", err)
+	}
+
 	// Ensure admin user exists with PIN
 	if err := database.ensureAdminExists(); err != nil {
 		log.Printf("Warning: failed to ensure admin exists: %v", err)
@@ -107,16 +191,61 @@ func (db *DB) createTables() error {
 		FOREIGN KEY(created_by) REFERENCES users(id)
 	);
 
+	-- Apparatus (department units)
+	CREATE TABLE IF NOT EXISTS apparatus (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		name TEXT NOT NULL UNIQUE,
+		unit_type TEXT NOT NULL DEFAULT '',
+		seats INTEGER NOT NULL DEFAULT 0,
+		pump_gpm INTEGER NOT NULL DEFAULT 0,
+		station TEXT NOT NULL DEFAULT '',
+		in_service BOOLEAN NOT NULL DEFAULT 1,
+		active BOOLEAN NOT NULL DEFAULT 1,
+		sort_order INTEGER NOT NULL DEFAULT 0,
+		created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+	);
+
 	-- Call apparatus junction table
 	CREATE TABLE IF NOT EXISTS call_apparatus (
 		id INTEGER PRIMARY KEY AUTOINCREMENT,
 		call_id INTEGER NOT NULL,
 		apparatus_id INTEGER NOT NULL,
 		FOREIGN KEY(call_id) REFERENCES calls(id) ON DELETE CASCADE,
-		FOREIGN KEY(apparatus_id) REFERENCES picklists(id),
+		FOREIGN KEY(apparatus_id) REFERENCES apparatus(id),
+		UNIQUE(call_id, apparatus_id)
+	);
+
+	-- Odometer, engine hour and pump hour readings per unit per call
+	CREATE TABLE IF NOT EXISTS apparatus_usage (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		call_id INTEGER NOT NULL,
+		apparatus_id INTEGER NOT NULL,
+		start_mileage REAL,
+		end_mileage REAL,
+		start_engine_hours REAL,
+		end_engine_hours REAL,
+		start_pump_hours REAL,
+		end_pump_hours REAL,
+		recorded_by INTEGER,
+		recorded_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+		FOREIGN KEY(call_id) REFERENCES calls(id) ON DELETE CASCADE,
+		FOREIGN KEY(apparatus_id) REFERENCES apparatus(id),
 		UNIQUE(call_id, apparatus_id)
 	);
 
+	-- Maintenance performed on a unit, with the readings at the time
+	CREATE TABLE IF NOT EXISTS apparatus_service (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		apparatus_id INTEGER NOT NULL,
+		service_date DATETIME NOT NULL,
+		mileage REAL,
+		engine_hours REAL,
+		pump_hours REAL,
+		notes TEXT NOT NULL DEFAULT '',
+		recorded_by INTEGER,
+		FOREIGN KEY(apparatus_id) REFERENCES apparatus(id)
+	);
+
 	-- Call responders junction table
 	CREATE TABLE IF NOT EXISTS call_responders (
 		id INTEGER PRIMARY KEY AUTOINCREMENT,
@@ -144,6 +273,207 @@ func (db *DB) createTables() error {
 		FOREIGN KEY(uploaded_by) REFERENCES users(id)
 	);
 
+	-- Patients treated on a call (EMS patient care)
+	CREATE TABLE IF NOT EXISTS call_patients (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		call_id INTEGER NOT NULL,
+		age_range TEXT NOT NULL DEFAULT '',
+		chief_complaint TEXT NOT NULL DEFAULT '',
+		care_level TEXT NOT NULL DEFAULT '',
+		transport_disposition TEXT NOT NULL DEFAULT '',
+		destination_hospital TEXT NOT NULL DEFAULT '',
+		treating_responder_id INTEGER,
+		created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+		FOREIGN KEY(call_id) REFERENCES calls(id) ON DELETE CASCADE,
+		FOREIGN KEY(treating_responder_id) REFERENCES users(id)
+	);
+
+	-- People involved in a call (owners, occupants, drivers, witnesses)
+	CREATE TABLE IF NOT EXISTS call_parties (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		call_id INTEGER NOT NULL,
+		role TEXT NOT NULL,
+		name TEXT NOT NULL DEFAULT '',
+		phone TEXT NOT NULL DEFAULT '',
+		address TEXT NOT NULL DEFAULT '',
+		vehicle_plate TEXT NOT NULL DEFAULT '',
+		vehicle_make TEXT NOT NULL DEFAULT '',
+		notes TEXT NOT NULL DEFAULT '',
+		created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+		FOREIGN KEY(call_id) REFERENCES calls(id) ON DELETE CASCADE
+	);
+
+	-- Vehicles involved in motor vehicle accidents
+	CREATE TABLE IF NOT EXISTS mva_vehicles (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		call_id INTEGER NOT NULL,
+		vehicle_year INTEGER NOT NULL DEFAULT 0,
+		make TEXT NOT NULL DEFAULT '',
+		model TEXT NOT NULL DEFAULT '',
+		plate TEXT NOT NULL DEFAULT '',
+		plate_state TEXT NOT NULL DEFAULT '',
+		damage TEXT NOT NULL DEFAULT '',
+		extrication BOOLEAN NOT NULL DEFAULT 0,
+		airbag_deployment TEXT NOT NULL DEFAULT '',
+		notes TEXT NOT NULL DEFAULT '',
+		FOREIGN KEY(call_id) REFERENCES calls(id) ON DELETE CASCADE
+	);
+
+	-- Involved parties (drivers, passengers, owners) linked to a vehicle
+	CREATE TABLE IF NOT EXISTS mva_vehicle_parties (
+		vehicle_id INTEGER NOT NULL,
+		party_id INTEGER NOT NULL,
+		PRIMARY KEY(vehicle_id, party_id),
+		FOREIGN KEY(vehicle_id) REFERENCES mva_vehicles(id) ON DELETE CASCADE,
+		FOREIGN KEY(party_id) REFERENCES call_parties(id) ON DELETE CASCADE
+	);
+
+	-- Fire cause, origin and loss details (one per call)
+	CREATE TABLE IF NOT EXISTS fire_details (
+		call_id INTEGER PRIMARY KEY,
+		area_of_origin TEXT NOT NULL DEFAULT '',
+		heat_source TEXT NOT NULL DEFAULT '',
+		cause_category TEXT NOT NULL DEFAULT '',
+		property_loss INTEGER NOT NULL DEFAULT 0,
+		contents_loss INTEGER NOT NULL DEFAULT 0,
+		value_saved INTEGER NOT NULL DEFAULT 0,
+		detectors_present TEXT NOT NULL DEFAULT '',
+		notes TEXT NOT NULL DEFAULT '',
+		updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+		FOREIGN KEY(call_id) REFERENCES calls(id) ON DELETE CASCADE
+	);
+
+	-- Narrative templates per call type
+	CREATE TABLE IF NOT EXISTS narrative_templates (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		call_type TEXT NOT NULL,
+		name TEXT NOT NULL,
+		body TEXT NOT NULL,
+		sort_order INTEGER NOT NULL DEFAULT 0,
+		active BOOLEAN NOT NULL DEFAULT 1
+	);
+
+	-- Photo and document attachments on calls
+	CREATE TABLE IF NOT EXISTS call_attachments (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		call_id INTEGER NOT NULL,
+		file_name TEXT NOT NULL,
+		mime_type TEXT NOT NULL,
+		size_bytes INTEGER NOT NULL,
+		sha256 TEXT NOT NULL,
+		caption TEXT NOT NULL DEFAULT '',
+		data BLOB NOT NULL,
+		uploaded_by INTEGER,
+		uploaded_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+		deleted_at DATETIME,
+		deleted_by INTEGER,
+		FOREIGN KEY(call_id) REFERENCES calls(id) ON DELETE CASCADE,
+		FOREIGN KEY(uploaded_by) REFERENCES users(id)
+	);
+
+	-- Typed links between related calls
+	CREATE TABLE IF NOT EXISTS call_links (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		call_id INTEGER NOT NULL,
+		linked_call_id INTEGER NOT NULL,
+		link_type TEXT NOT NULL,
+		notes TEXT NOT NULL DEFAULT '',
+		created_by INTEGER,
+		created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+		FOREIGN KEY(call_id) REFERENCES calls(id) ON DELETE CASCADE,
+		FOREIGN KEY(linked_call_id) REFERENCES calls(id) ON DELETE CASCADE,
+		UNIQUE(call_id, linked_call_id, link_type)
+	);
+
+	-- Department-specific custom call fields
+	CREATE TABLE IF NOT EXISTS custom_fields (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		field_name TEXT NOT NULL UNIQUE,
+		label TEXT NOT NULL,
+		field_type TEXT NOT NULL,
+		picklist_category TEXT NOT NULL DEFAULT '',
+		required BOOLEAN NOT NULL DEFAULT 0,
+		active BOOLEAN NOT NULL DEFAULT 1,
+		sort_order INTEGER NOT NULL DEFAULT 0
+	);
+
+	-- Custom field values per call
+	CREATE TABLE IF NOT EXISTS call_custom_values (
+		call_id INTEGER NOT NULL,
+		custom_field_id INTEGER NOT NULL,
+		value TEXT NOT NULL,
+		PRIMARY KEY(call_id, custom_field_id),
+		FOREIGN KEY(call_id) REFERENCES calls(id) ON DELETE CASCADE,
+		FOREIGN KEY(custom_field_id) REFERENCES custom_fields(id)
+	);
+
+	-- Catalog of consumables and equipment that can be used on calls
+	CREATE TABLE IF NOT EXISTS inventory_items (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		name TEXT NOT NULL UNIQUE,
+		category TEXT NOT NULL DEFAULT '',
+		unit TEXT NOT NULL DEFAULT 'each',
+		unit_cost_cents INTEGER NOT NULL DEFAULT 0,
+		active BOOLEAN NOT NULL DEFAULT 1,
+		sort_order INTEGER NOT NULL DEFAULT 0
+	);
+
+	-- Quantities of inventory items used on a call
+	CREATE TABLE IF NOT EXISTS call_inventory_usage (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		call_id INTEGER NOT NULL,
+		item_id INTEGER NOT NULL,
+		quantity REAL NOT NULL,
+		notes TEXT NOT NULL DEFAULT '',
+		FOREIGN KEY(call_id) REFERENCES calls(id) ON DELETE CASCADE,
+		FOREIGN KEY(item_id) REFERENCES inventory_items(id)
+	);
+
+	-- Training, drill, meeting and detail events (kept apart from emergency calls)
+	CREATE TABLE IF NOT EXISTS events (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		event_number TEXT NOT NULL UNIQUE,
+		event_type TEXT NOT NULL,
+		topic TEXT NOT NULL,
+		instructor TEXT NOT NULL DEFAULT '',
+		location TEXT NOT NULL DEFAULT '',
+		start_time DATETIME NOT NULL,
+		hours REAL NOT NULL DEFAULT 0,
+		notes TEXT NOT NULL DEFAULT '',
+		created_by INTEGER,
+		created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+		updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+	);
+
+	-- Members who attended an event
+	CREATE TABLE IF NOT EXISTS event_attendance (
+		event_id INTEGER NOT NULL,
+		user_id INTEGER NOT NULL,
+		PRIMARY KEY(event_id, user_id),
+		FOREIGN KEY(event_id) REFERENCES events(id) ON DELETE CASCADE,
+		FOREIGN KEY(user_id) REFERENCES users(id)
+	);
+
+	-- Standard incident type codes (bundled reference data)
+	CREATE TABLE IF NOT EXISTS incident_type_codes (
+		code TEXT PRIMARY KEY,
+		description TEXT NOT NULL,
+		series TEXT NOT NULL
+	);
+
+	-- Named call filters, private to their owner or shared department-wide
+	CREATE TABLE IF NOT EXISTS saved_filters (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		name TEXT NOT NULL,
+		owner_id INTEGER NOT NULL,
+		shared BOOLEAN NOT NULL DEFAULT 0,
+		filter TEXT NOT NULL,
+		created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+		updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+		FOREIGN KEY(owner_id) REFERENCES users(id),
+		UNIQUE(owner_id, name)
+	);
+
 	-- Audit log table
 	CREATE TABLE IF NOT EXISTS audit_log (
 		id INTEGER PRIMARY KEY AUTOINCREMENT,
@@ -162,6 +492,19 @@ func (db *DB) createTables() error {
 	CREATE INDEX IF NOT EXISTS idx_calls_town ON calls(town);
 	CREATE INDEX IF NOT EXISTS idx_picklists_category ON picklists(category);
 	CREATE INDEX IF NOT EXISTS idx_picklists_active ON picklists(active);
+	CREATE INDEX IF NOT EXISTS idx_call_patients_call_id ON call_patients(call_id);
+	CREATE INDEX IF NOT EXISTS idx_narrative_templates_call_type ON narrative_templates(call_type);
+	CREATE INDEX IF NOT EXISTS idx_call_attachments_call_id ON call_attachments(call_id);
+	CREATE INDEX IF NOT EXISTS idx_call_links_call_id ON call_links(call_id);
+	CREATE INDEX IF NOT EXISTS idx_call_links_linked_call_id ON call_links(linked_call_id);
+	CREATE INDEX IF NOT EXISTS idx_apparatus_usage_apparatus_id ON apparatus_usage(apparatus_id);
+	CREATE INDEX IF NOT EXISTS idx_apparatus_service_apparatus_id ON apparatus_service(apparatus_id);
+	CREATE INDEX IF NOT EXISTS idx_call_parties_call_id ON call_parties(call_id);
+	CREATE INDEX IF NOT EXISTS idx_mva_vehicles_call_id ON mva_vehicles(call_id);
+	CREATE INDEX IF NOT EXISTS idx_call_inventory_usage_call_id ON call_inventory_usage(call_id);
+	CREATE INDEX IF NOT EXISTS idx_events_start_time ON events(start_time);
+	CREATE INDEX IF NOT EXISTS idx_event_attendance_user_id ON event_attendance(user_id);
+	CREATE INDEX IF NOT EXISTS idx_saved_filters_shared ON saved_filters(shared);
 	`
 
 	_, err := db.Exec(schema)
@@ -203,10 +546,9 @@ func (db *DB) seedDefaultData() error {
 		values     []string
 		sortOrders []int
 	}{
-		{"call_type", []string{"Structure Fire", "Vehicle Fire", "Grass Fire", "Medical Emergency", "Motor Vehicle Accident", "Hazmat", "Rescue", "Alarm Investigation", "Mutual Aid", "Training"}, []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}},
-		{"mutual_aid", []string{"No", "Yes"}, []int{1, 2}},
+		{"call_type", []string{"Structure Fire", "Vehicle Fire", "Grass Fire", "Medical Emergency", "Motor Vehicle Accident", "Hazmat", "Rescue", "Alarm Investigation", "Mutual Aid"}, []int{1, 2, 3, 4, 5, 6, 7, 8, 9}},
+		{"mutual_aid", []string{MutualAidNone, MutualAidGiven, MutualAidReceived}, []int{1, 2, 3}},
 		{"mutual_aid_agencies", []string{"Readsboro Fire Dept", "Bennington Fire Dept", "Pownal Fire Dept", "Wilmington Fire Dept", "Searsburg Fire Dept"}, []int{1, 2, 3, 4, 5}},
-		{"apparatus", []string{"Engine 1", "Engine 2", "Truck 1", "Rescue 1", "Ambulance 1", "Chief", "Tanker 1"}, []int{1, 2, 3, 4, 5, 6, 7}},
 		{"town", []string{"Stamford", "Readsboro", "Whitingham"}, []int{1, 2, 3}},
 		{"responder_role", []string{"Driver", "Officer", "Firefighter", "EMT", "Medic", "Chief"}, []int{1, 2, 3, 4, 5, 6}},
 		{"position", []string{"Chief", "Deputy Chief", "Captain", "Member", "Probationary"}, []int{1, 2, 3, 4, 5}},
@@ -225,6 +567,29 @@ func (db *DB) seedDefaultData() error {
 		}
 	}
 
+	// Default apparatus
+	apparatusData := []struct {
+		name     string
+		unitType string
+	}{
+		{"Engine 1", "Engine"},
+		{"Engine 2", "Engine"},
+		{"Truck 1", "Ladder/Truck"},
+		{"Rescue 1", "Rescue"},
+		{"Ambulance 1", "Ambulance"},
+		{"Chief", "Command"},
+		{"Tanker 1", "Tanker"},
+	}
+	for i, unit := range apparatusData {
+		_, err = db.Exec(`
+			INSERT OR IGNORE INTO apparatus (name, unit_type, sort_order)
+			VALUES (?, ?, ?)
+		`, unit.name, unit.unitType, i+1)
+		if err != nil {
+			return err
+		}
+	}
+
 	// Default form fields
 	formFields := []struct {
 		fieldName string
@@ -340,3 +705,62 @@ func (db *DB) ensureLogoTable() error {
 	_, err := db.Exec(schema)
 	return err
 }
+
+// ensurePicklistCategory seeds a picklist category that was added after a
+// database was first created. Categories that already have rows are left
+// alone so admin edits are preserved.
+func (db *DB) ensurePicklistCategory(category string, values []string) error {
+	var count int
+	err := db.QueryRow("SELECT COUNT(*) FROM picklists WHERE category = ?", category).Scan(&count)
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return nil
+	}
+
+	for i, value := range values {
+		_, err = db.Exec(`
+			INSERT OR IGNORE INTO picklists (category, value, sort_order, active)
+			VALUES (?, ?, ?, 1)
+		`, category, value, i+1)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// placeholders returns n comma-separated SQL bind markers for an IN clause
+func placeholders(n int) string {
+	if n <= 0 {
+		return ""
+	}
+	return strings.Repeat("?, ", n-1) + "?"
+}
+
+// ensureColumn adds a column to a table if it does not exist yet (migration helper)
+func (db *DB) ensureColumn(table, column, definition string) error {
+	rows, err := db.Query("SELECT name FROM pragma_table_info(?)", table)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return err
+		}
+		if name == column {
+			return nil
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	rows.Close()
+
+	_, err = db.Exec(fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s %s", table, column, definition))
+	return err
+}
@@ -0,0 +1,293 @@
+package db
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+)
+
+// incidentTypeSeries names the NFIRS incident type series by leading digit
+var incidentTypeSeries = map[byte]string{
+	'1': "Fire",
+	'2': "Overpressure Rupture, Explosion, Overheat (No Fire)",
+	'3': "Rescue & Emergency Medical Service",
+	'4': "Hazardous Condition (No Fire)",
+	'5': "Service Call",
+	'6': "Good Intent Call",
+	'7': "False Alarm & False Call",
+	'8': "Severe Weather & Natural Disaster",
+	'9': "Special Incident Type",
+}
+
+// nfirsIncidentTypes is the bundled NFIRS 5.0 incident type reference table.
+// It covers the codes a small rural department reports; codes can be added
+// here and are seeded into incident_type_codes on startup.
+var nfirsIncidentTypes = []struct {
+	code        string
+	description string
+}{
+	{"100", "Fire, other"},
+	{"111", "Building fire"},
+	{"112", "Fires in structure other than in a building"},
+	{"113", "Cooking fire, confined to container"},
+	{"114", "Chimney or flue fire, confined to chimney or flue"},
+	{"116", "Fuel burner/boiler malfunction, fire confined"},
+	{"118", "Trash or rubbish fire, contained"},
+	{"130", "Mobile property (vehicle) fire, other"},
+	{"131", "Passenger vehicle fire"},
+	{"132", "Road freight or transport vehicle fire"},
+	{"138", "Off-road vehicle or heavy equipment fire"},
+	{"140", "Natural vegetation fire, other"},
+	{"141", "Forest, woods or wildland fire"},
+	{"142", "Brush or brush-and-grass mixture fire"},
+	{"143", "Grass fire"},
+	{"150", "Outside rubbish fire, other"},
+	{"151", "Outside rubbish, trash or waste fire"},
+	{"160", "Special outside fire, other"},
+	{"200", "Overpressure rupture, explosion, overheat, other"},
+	{"251", "Excessive heat, scorch burns with no ignition"},
+	{"300", "Rescue, EMS incident, other"},
+	{"311", "Medical assist, assist EMS crew"},
+	{"320", "Emergency medical service incident, other"},
+	{"321", "EMS call, excluding vehicle accident with injury"},
+	{"322", "Motor vehicle accident with injuries"},
+	{"323", "Motor vehicle/pedestrian accident (MV Ped)"},
+	{"324", "Motor vehicle accident with no injuries"},
+	{"331", "Lock-in (if lock out, use 511)"},
+	{"341", "Search for person on land"},
+	{"342", "Search for person in water"},
+	{"350", "Extrication, rescue, other"},
+	{"352", "Extrication of victim(s) from vehicle"},
+	{"353", "Removal of victim(s) from stalled elevator"},
+	{"360", "Water & ice-related rescue, other"},
+	{"361", "Swimming/recreational water areas rescue"},
+	{"363", "Swift water rescue"},
+	{"365", "Watercraft rescue"},
+	{"381", "Rescue or EMS standby"},
+	{"400", "Hazardous condition, other"},
+	{"411", "Gasoline or other flammable liquid spill"},
+	{"412", "Gas leak (natural gas or LPG)"},
+	{"413", "Oil or other combustible liquid spill"},
+	{"424", "Carbon monoxide incident"},
+	{"440", "Electrical wiring/equipment problem, other"},
+	{"444", "Power line down"},
+	{"445", "Arcing, shorted electrical equipment"},
+	{"460", "Accident, potential accident, other"},
+	{"463", "Vehicle accident, general cleanup"},
+	{"500", "Service call, other"},
+	{"510", "Person in distress, other"},
+	{"511", "Lock-out"},
+	{"520", "Water problem, other"},
+	{"522", "Water or steam leak"},
+	{"531", "Smoke or odor removal"},
+	{"550", "Public service assistance, other"},
+	{"551", "Assist police or other governmental agency"},
+	{"552", "Police matter"},
+	{"553", "Public service"},
+	{"554", "Assist invalid"},
+	{"561", "Unauthorized burning"},
+	{"571", "Cover assignment, standby, moveup"},
+	{"600", "Good intent call, other"},
+	{"611", "Dispatched and cancelled en route"},
+	{"622", "No incident found on arrival at dispatch address"},
+	{"631", "Authorized controlled burning"},
+	{"651", "Smoke scare, odor of smoke"},
+	{"652", "Steam, vapor, fog or dust thought to be smoke"},
+	{"671", "HazMat release investigation w/no HazMat"},
+	{"700", "False alarm or false call, other"},
+	{"711", "Municipal alarm system, malicious false alarm"},
+	{"730", "System malfunction, other"},
+	{"733", "Smoke detector activation due to malfunction"},
+	{"735", "Alarm system sounded due to malfunction"},
+	{"736", "CO detector activation due to malfunction"},
+	{"740", "Unintentional transmission of alarm, other"},
+	{"743", "Smoke detector activation, no fire - unintentional"},
+	{"745", "Alarm system activation, no fire - unintentional"},
+	{"746", "Carbon monoxide detector activation, no CO"},
+	{"800", "Severe weather or natural disaster, other"},
+	{"813", "Wind storm, tornado/hurricane assessment"},
+	{"814", "Lightning strike (no fire)"},
+	{"900", "Special type of incident, other"},
+	{"911", "Citizen complaint"},
+}
+
+// defaultCallTypeCodes maps the seeded call_type picklist values to their
+// closest NFIRS category code
+var defaultCallTypeCodes = map[string]string{
+	"Structure Fire":         "111",
+	"Vehicle Fire":           "131",
+	"Grass Fire":             "143",
+	"Medical Emergency":      "321",
+	"Motor Vehicle Accident": "322",
+	"Hazmat":                 "400",
+	"Rescue":                 "300",
+	"Alarm Investigation":    "700",
+}
+
+// ensureIncidentTypeCodes adds the code columns, loads the bundled reference
+// table and assigns default codes to seeded call types that have none
+func (db *DB) ensureIncidentTypeCodes() error {
+	if err := db.ensureColumn("picklists", "code", "TEXT NOT NULL DEFAULT ''"); err != nil {
+		return err
+	}
+	if err := db.ensureColumn("picklists", "code_description", "TEXT NOT NULL DEFAULT ''"); err != nil {
+		return err
+	}
+	if err := db.ensureColumn("calls", "incident_type_code", "TEXT NOT NULL DEFAULT ''"); err != nil {
+		return err
+	}
+
+	for _, c := range nfirsIncidentTypes {
+		_, err := db.Exec(`
+			INSERT OR REPLACE INTO incident_type_codes (code, description, series)
+			VALUES (?, ?, ?)
+		`, c.code, c.description, incidentTypeSeries[c.code[0]])
+		if err != nil {
+			return err
+		}
+	}
+
+	for value, code := range defaultCallTypeCodes {
+		_, err := db.Exec(`
+			UPDATE picklists
+			SET code = ?, code_description = (SELECT description FROM incident_type_codes WHERE code = ?)
+			WHERE category = 'call_type' AND value = ? AND code = ''
+		`, code, code, value)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetIncidentTypeCodes returns the full incident type reference table
+func (db *DB) GetIncidentTypeCodes() ([]IncidentTypeCode, error) {
+	rows, err := db.Query(`
+		SELECT code, description, series
+		FROM incident_type_codes
+		ORDER BY code
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var codes []IncidentTypeCode
+	for rows.Next() {
+		var c IncidentTypeCode
+		if err := rows.Scan(&c.Code, &c.Description, &c.Series); err != nil {
+			return nil, err
+		}
+		codes = append(codes, c)
+	}
+	return codes, nil
+}
+
+// GetIncidentTypeCode looks up a single incident type code, returning nil if unknown
+func (db *DB) GetIncidentTypeCode(code string) (*IncidentTypeCode, error) {
+	var c IncidentTypeCode
+	err := db.QueryRow(`
+		SELECT code, description, series
+		FROM incident_type_codes WHERE code = ?
+	`, code).Scan(&c.Code, &c.Description, &c.Series)
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+// ValidateIncidentTypeCode returns an error if code is set but not in the
+// reference table. An empty code is valid.
+func (db *DB) ValidateIncidentTypeCode(code string) error {
+	if code == "" {
+		return nil
+	}
+	c, err := db.GetIncidentTypeCode(code)
+	if err != nil {
+		return err
+	}
+	if c == nil {
+		return fmt.Errorf("unknown incident type code: %s", code)
+	}
+	return nil
+}
+
+// SetPicklistCode assigns a standard code to a call_type picklist item. The
+// description defaults to the reference table description when left empty.
+func (db *DB) SetPicklistCode(id int, code, description string) error {
+	if code != "" {
+		c, err := db.GetIncidentTypeCode(code)
+		if err != nil {
+			return err
+		}
+		if c == nil {
+			return fmt.Errorf("unknown incident type code: %s", code)
+		}
+		if description == "" {
+			description = c.Description
+		}
+	}
+
+	result, err := db.Exec(`
+		UPDATE picklists
+		SET code = ?, code_description = ?
+		WHERE id = ? AND category = 'call_type'
+	`, code, description, id)
+	if err != nil {
+		return err
+	}
+	updated, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if updated == 0 {
+		return fmt.Errorf("call type %d not found", id)
+	}
+	return nil
+}
+
+// ResolveIncidentTypeCode returns the standard incident type for a call: its
+// own code when set, otherwise the code of its call_type picklist item
+func (db *DB) ResolveIncidentTypeCode(call *Call) (string, error) {
+	if call.IncidentTypeCode != "" {
+		return call.IncidentTypeCode, nil
+	}
+
+	var code string
+	err := db.QueryRow(`
+		SELECT code FROM picklists WHERE category = 'call_type' AND value = ?
+	`, call.CallType).Scan(&code)
+	if err == sql.ErrNoRows {
+		return "", nil
+	}
+	return code, err
+}
+
+// requireIncidentSeries returns an error unless a call's resolved incident
+// type code is in series, the leading digit of an NFIRS code. what names
+// the records being added, for the error message.
+func (db *DB) requireIncidentSeries(callID int, series byte, what string) error {
+	var call Call
+	err := db.QueryRow(`
+		SELECT call_type, incident_type_code FROM calls WHERE id = ?
+	`, callID).Scan(&call.CallType, &call.IncidentTypeCode)
+	if err == sql.ErrNoRows {
+		return errors.New("call not found")
+	}
+	if err != nil {
+		return err
+	}
+
+	code, err := db.ResolveIncidentTypeCode(&call)
+	if err != nil {
+		return err
+	}
+	if code == "" || code[0] != series {
+		return fmt.Errorf("%s can only be added to %s calls", what, incidentTypeSeries[series])
+	}
+	return nil
+}
@@ -0,0 +1,92 @@
+package db
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDefaultCallTypeCodes(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	items, err := db.GetPicklistByCategory("call_type")
+	if err != nil {
+		t.Fatalf("Failed to get call types: %v", err)
+	}
+	for _, item := range items {
+		if item.Value == "Structure Fire" {
+			if item.Code != "111" || item.CodeDescription != "Building fire" {
+				t.Errorf("Expected Structure Fire code 111/Building fire, got %s/%s", item.Code, item.CodeDescription)
+			}
+			return
+		}
+	}
+	t.Error("Structure Fire call type not found")
+}
+
+func TestCallIncidentTypeCodeValidation(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	call := &Call{
+		CallType:         "Structure Fire",
+		Address:          "1 Main St",
+		Dispatched:       time.Now(),
+		Narrative:        "Chimney fire",
+		IncidentTypeCode: "999",
+		CreatedBy:        1,
+	}
+	if err := db.CreateCall(call, nil, nil, nil); err == nil {
+		t.Fatal("Expected error for unknown incident type code")
+	}
+
+	call.IncidentTypeCode = "114"
+	if err := db.CreateCall(call, nil, nil, nil); err != nil {
+		t.Fatalf("Failed to create call with valid code: %v", err)
+	}
+
+	code, err := db.ResolveIncidentTypeCode(call)
+	if err != nil {
+		t.Fatalf("Failed to resolve code: %v", err)
+	}
+	if code != "114" {
+		t.Errorf("Expected resolved code 114, got %s", code)
+	}
+
+	call.IncidentTypeCode = ""
+	code, err = db.ResolveIncidentTypeCode(call)
+	if err != nil {
+		t.Fatalf("Failed to resolve code: %v", err)
+	}
+	if code != "111" {
+		t.Errorf("Expected fallback code 111, got %s", code)
+	}
+}
+
+func TestSetPicklistCodeOnlyCallTypes(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	callTypes, err := db.GetPicklistByCategory("call_type")
+	if err != nil || len(callTypes) == 0 {
+		t.Fatalf("Expected seeded call types, got %d (%v)", len(callTypes), err)
+	}
+	if err := db.SetPicklistCode(callTypes[0].ID, "111", ""); err != nil {
+		t.Fatalf("Failed to set call type code: %v", err)
+	}
+	item, err := db.GetPicklistItem(callTypes[0].ID)
+	if err != nil || item.Code != "111" || item.CodeDescription != "Building fire" {
+		t.Errorf("Expected code 111 with the reference description, got %+v (%v)", item, err)
+	}
+
+	towns, err := db.GetPicklistByCategory("town")
+	if err != nil || len(towns) == 0 {
+		t.Fatalf("Expected seeded towns, got %d (%v)", len(towns), err)
+	}
+	if err := db.SetPicklistCode(towns[0].ID, "111", ""); err == nil {
+		t.Error("Expected error setting a code on a town")
+	}
+	if err := db.SetPicklistCode(9999, "111", ""); err == nil {
+		t.Error("Expected error setting a code on a missing item")
+	}
+}
@@ -6,16 +6,16 @@ import (
 
 // User represents a fire department member
 type User struct {
-	ID         int       `json:"id"`
-	FirstName  string    `json:"first_name"`
-	LastName   string    `json:"last_name"`
-	Position   string    `json:"position"` // "chief", "captain", "member", "probationary"
-	EMSLevel   string    `json:"ems_level"` // "VEFR", "EMR", "EMT", "AEMT", "Paramedic", "None"
-	IsAdmin    bool      `json:"is_admin"`
-	PIN        string    `json:"pin,omitempty"`
-	Active     bool      `json:"active"`
+	ID         int        `json:"id"`
+	FirstName  string     `json:"first_name"`
+	LastName   string     `json:"last_name"`
+	Position   string     `json:"position"`  // "chief", "captain", "member", "probationary"
+	EMSLevel   string     `json:"ems_level"` // "VEFR", "EMR", "EMT", "AEMT", "Paramedic", "None"
+	IsAdmin    bool       `json:"is_admin"`
+	PIN        string     `json:"pin,omitempty"`
+	Active     bool       `json:"active"`
 	JoinedDate *time.Time `json:"joined_date,omitempty"`
-	Created    time.Time `json:"created"`
+	Created    time.Time  `json:"created"`
 }
 
 // Picklist represents dropdown values for various categories
@@ -25,6 +25,16 @@ type Picklist struct {
 	Value     string `json:"value"`
 	SortOrder int    `json:"sort_order"`
 	Active    bool   `json:"active"`
+	// Standard reporting code (e.g. NFIRS incident type for call_type items)
+	Code            string `json:"code"`
+	CodeDescription string `json:"code_description"`
+}
+
+// IncidentTypeCode is an entry in the bundled standard incident type reference table
+type IncidentTypeCode struct {
+	Code        string `json:"code"`
+	Description string `json:"description"`
+	Series      string `json:"series"`
 }
 
 // FormField represents form configuration
@@ -37,23 +47,185 @@ type FormField struct {
 	SortOrder int    `json:"sort_order"`
 }
 
+// CustomField is a department-specific call field defined by an admin
+type CustomField struct {
+	ID               int    `json:"id"`
+	FieldName        string `json:"field_name"`
+	Label            string `json:"label"`
+	FieldType        string `json:"field_type"`        // text, number, picklist, checkbox, datetime
+	PicklistCategory string `json:"picklist_category"` // for picklist fields
+	Required         bool   `json:"required"`
+	Active           bool   `json:"active"`
+	SortOrder        int    `json:"sort_order"`
+}
+
 // Call represents a fire department call
 type Call struct {
-	ID             int       `json:"id"`
-	IncidentNumber string    `json:"incident_number"`
-	CallType       string    `json:"call_type"`
-	MutualAid      string    `json:"mutual_aid"`
-	Address        string    `json:"address"`
-	Town           string    `json:"town"`
-	LocationNotes  string    `json:"location_notes"`
-	Dispatched     time.Time `json:"dispatched"`
-	Enroute        *time.Time `json:"enroute"`
-	OnScene        *time.Time `json:"on_scene"`
-	Clear          *time.Time `json:"clear"`
-	Narrative      string    `json:"narrative"`
-	CreatedBy      int       `json:"created_by"`
-	CreatedAt      time.Time `json:"created_at"`
-	UpdatedAt      time.Time `json:"updated_at"`
+	ID               int               `json:"id"`
+	IncidentNumber   string            `json:"incident_number"`
+	CallType         string            `json:"call_type"`
+	MutualAid        string            `json:"mutual_aid"`
+	Address          string            `json:"address"`
+	Town             string            `json:"town"`
+	LocationNotes    string            `json:"location_notes"`
+	Dispatched       time.Time         `json:"dispatched"`
+	Enroute          *time.Time        `json:"enroute"`
+	OnScene          *time.Time        `json:"on_scene"`
+	Clear            *time.Time        `json:"clear"`
+	Narrative        string            `json:"narrative"`
+	IncidentTypeCode string            `json:"incident_type_code"` // more specific than the call_type picklist code
+	AddressKey       string            `json:"address_key"`        // normalized address for premise matching
+	TemperatureF     *int              `json:"temperature_f"`      // conditions at the time of the call, all optional
+	Wind             string            `json:"wind"`               // picklist "weather_wind"
+	Precipitation    string            `json:"precipitation"`      // picklist "precipitation"
+	RoadConditions   string            `json:"road_conditions"`    // picklist "road_conditions"
+	CreatedBy        int               `json:"created_by"`
+	CreatedAt        time.Time         `json:"created_at"`
+	UpdatedAt        time.Time         `json:"updated_at"`
+	Links            []CallLink        `json:"links,omitempty"`         // populated by GetCallByID and AttachCallLinks
+	CustomFields     map[string]string `json:"custom_fields,omitempty"` // custom field values keyed by field name
+}
+
+// CallLink is a typed link from one call to a related call
+type CallLink struct {
+	ID                   int       `json:"id"`
+	CallID               int       `json:"call_id"`
+	LinkedCallID         int       `json:"linked_call_id"`
+	LinkType             string    `json:"link_type"` // e.g. "rekindle-of", or "rekindled-by" from the original call
+	Notes                string    `json:"notes"`
+	LinkedIncidentNumber string    `json:"linked_incident_number"`
+	LinkedCallType       string    `json:"linked_call_type"`
+	LinkedDispatched     time.Time `json:"linked_dispatched"`
+}
+
+// CallTypeCount is the number of calls of one call type
+type CallTypeCount struct {
+	CallType string `json:"call_type"`
+	Count    int    `json:"count"`
+}
+
+// CallStats summarizes the calls in a period, each figure compared with
+// the period just before it (the same number of calendar months when the
+// period is whole months, otherwise the same length of time)
+type CallStats struct {
+	Start             time.Time    `json:"start"`
+	End               time.Time    `json:"end"`
+	PreviousStart     time.Time    `json:"previous_start"`
+	PreviousEnd       time.Time    `json:"previous_end"`
+	Total             StatCount    `json:"total"`
+	MutualAidGiven    StatCount    `json:"mutual_aid_given"`
+	MutualAidReceived StatCount    `json:"mutual_aid_received"`
+	ByCallType        []StatBucket `json:"by_call_type"`   // busiest first
+	ByTown            []StatBucket `json:"by_town"`        // busiest first
+	ByMonth           []StatBucket `json:"by_month"`       // "2026-01", in order
+	ByDayOfWeek       []StatBucket `json:"by_day_of_week"` // "Sunday" through "Saturday"
+	ByHour            []StatBucket `json:"by_hour"`        // "00" through "23"
+}
+
+// StatCount is a count in the current and previous periods.
+// PercentChange is nil when the previous count is zero.
+type StatCount struct {
+	Current       int      `json:"current"`
+	Previous      int      `json:"previous"`
+	Change        int      `json:"change"`
+	PercentChange *float64 `json:"percent_change"`
+}
+
+// StatBucket is one row of a CallStats breakdown. For months, PreviousKey
+// names the month of the previous period it is compared with.
+type StatBucket struct {
+	Key         string `json:"key"`
+	PreviousKey string `json:"previous_key,omitempty"`
+	StatCount
+}
+
+// PremiseHistory lists prior calls at one normalized address a page at a
+// time. Total and CountsByType cover every call at the premise.
+type PremiseHistory struct {
+	AddressKey   string          `json:"address_key"`
+	Town         string          `json:"town"`
+	Calls        []Call          `json:"calls"`
+	Total        int             `json:"total"`
+	NextCursor   string          `json:"next_cursor"`
+	CountsByType []CallTypeCount `json:"counts_by_type"`
+}
+
+// AddressSuggestion is a previously used address offered for autocomplete
+type AddressSuggestion struct {
+	Address   string `json:"address"`
+	Town      string `json:"town"`
+	CallCount int    `json:"call_count"`
+}
+
+// DuplicateWarning is returned instead of saving a call when possible
+// duplicates exist that the user has not acknowledged
+type DuplicateWarning struct {
+	Message    string `json:"message"`
+	Duplicates []Call `json:"duplicates"`
+}
+
+// DuplicatePair is two saved calls that are likely the same incident
+type DuplicatePair struct {
+	First  Call   `json:"first"`
+	Second Call   `json:"second"`
+	Reason string `json:"reason"`
+}
+
+// Apparatus is a department unit that can be assigned to calls
+type Apparatus struct {
+	ID        int       `json:"id"`
+	Name      string    `json:"name"`
+	UnitType  string    `json:"unit_type"` // picklist "apparatus_type"
+	Seats     int       `json:"seats"`
+	PumpGPM   int       `json:"pump_gpm"` // rated pump capacity, 0 if none
+	Station   string    `json:"station"`
+	InService bool      `json:"in_service"`
+	Active    bool      `json:"active"` // false once the unit is retired
+	SortOrder int       `json:"sort_order"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// ApparatusUsage is one unit's odometer, engine hour and pump hour readings
+// for a call. Readings are optional but must never go backwards.
+type ApparatusUsage struct {
+	ID               int       `json:"id"`
+	CallID           int       `json:"call_id"`
+	ApparatusID      int       `json:"apparatus_id"`
+	StartMileage     *float64  `json:"start_mileage"`
+	EndMileage       *float64  `json:"end_mileage"`
+	StartEngineHours *float64  `json:"start_engine_hours"`
+	EndEngineHours   *float64  `json:"end_engine_hours"`
+	StartPumpHours   *float64  `json:"start_pump_hours"`
+	EndPumpHours     *float64  `json:"end_pump_hours"`
+	RecordedBy       int       `json:"recorded_by"`
+	RecordedAt       time.Time `json:"recorded_at"`
+	ApparatusName    string    `json:"apparatus_name"`
+	IncidentNumber   string    `json:"incident_number"`
+	Dispatched       time.Time `json:"dispatched"`
+}
+
+// ApparatusService records maintenance performed on a unit
+type ApparatusService struct {
+	ID          int       `json:"id"`
+	ApparatusID int       `json:"apparatus_id"`
+	ServiceDate time.Time `json:"service_date"`
+	Mileage     *float64  `json:"mileage"`
+	EngineHours *float64  `json:"engine_hours"`
+	PumpHours   *float64  `json:"pump_hours"`
+	Notes       string    `json:"notes"`
+	RecordedBy  int       `json:"recorded_by"`
+}
+
+// MaintenanceDue is a unit that has passed a maintenance threshold since
+// its last service
+type MaintenanceDue struct {
+	Apparatus               Apparatus  `json:"apparatus"`
+	LastServiceDate         *time.Time `json:"last_service_date"`
+	CurrentMileage          float64    `json:"current_mileage"`
+	MilesSinceService       float64    `json:"miles_since_service"`
+	EngineHoursSinceService float64    `json:"engine_hours_since_service"`
+	PumpHoursSinceService   float64    `json:"pump_hours_since_service"`
+	Reasons                 []string   `json:"reasons"`
 }
 
 // CallApparatus represents apparatus assigned to a call
@@ -71,6 +243,188 @@ type CallResponder struct {
 	ResponderRole string `json:"responder_role,omitempty"`
 }
 
+// Patient represents a patient treated on a call
+type Patient struct {
+	ID                   int       `json:"id"`
+	CallID               int       `json:"call_id"`
+	AgeRange             string    `json:"age_range"` // picklist "patient_age_range"
+	ChiefComplaint       string    `json:"chief_complaint"`
+	CareLevel            string    `json:"care_level"`            // picklist "care_level"
+	TransportDisposition string    `json:"transport_disposition"` // picklist "transport_disposition"
+	DestinationHospital  string    `json:"destination_hospital"`  // picklist "hospital"
+	TreatingResponderID  *int      `json:"treating_responder_id"`
+	CreatedAt            time.Time `json:"created_at"`
+}
+
+// Party is a person involved in a call, such as a property owner or a
+// driver in an MVA. Fields tagged pii:"true" hold personal information and
+// are blanked by RedactPII for non-privileged exports.
+type Party struct {
+	ID           int       `json:"id"`
+	CallID       int       `json:"call_id"`
+	Role         string    `json:"role"` // picklist "party_role"
+	Name         string    `json:"name" pii:"true"`
+	Phone        string    `json:"phone" pii:"true"`
+	Address      string    `json:"address" pii:"true"`
+	VehiclePlate string    `json:"vehicle_plate" pii:"true"`
+	VehicleMake  string    `json:"vehicle_make"`
+	Notes        string    `json:"notes" pii:"true"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// MVAVehicle is a vehicle involved in a motor vehicle accident. PartyIDs
+// link the drivers, passengers and owners recorded in call_parties.
+type MVAVehicle struct {
+	ID               int    `json:"id"`
+	CallID           int    `json:"call_id"`
+	Year             int    `json:"year"` // 0 if unknown
+	Make             string `json:"make"`
+	Model            string `json:"model"`
+	Plate            string `json:"plate" pii:"true"`
+	PlateState       string `json:"plate_state"`
+	Damage           string `json:"damage"` // picklist "vehicle_damage"
+	Extrication      bool   `json:"extrication"`
+	AirbagDeployment string `json:"airbag_deployment"` // picklist "airbag_deployment"
+	Notes            string `json:"notes"`
+	PartyIDs         []int  `json:"party_ids"`
+}
+
+// MVAIncident is an MVA call with its vehicles
+type MVAIncident struct {
+	Call     Call         `json:"call"`
+	Vehicles []MVAVehicle `json:"vehicles"`
+}
+
+// MVALocationReport summarizes MVAs on a road segment or at an address
+type MVALocationReport struct {
+	Road              string        `json:"road"`
+	Town              string        `json:"town"`
+	Incidents         []MVAIncident `json:"incidents"`
+	Vehicles          int           `json:"vehicles"`
+	Extrications      int           `json:"extrications"`
+	AirbagDeployments int           `json:"airbag_deployments"`
+}
+
+// FireDetails represents the cause, origin and loss record for a fire call.
+// Dollar amounts are whole dollars.
+type FireDetails struct {
+	CallID           int       `json:"call_id"`
+	AreaOfOrigin     string    `json:"area_of_origin"` // picklist "fire_area_of_origin"
+	HeatSource       string    `json:"heat_source"`    // picklist "fire_heat_source"
+	CauseCategory    string    `json:"cause_category"` // picklist "fire_cause"
+	PropertyLoss     int       `json:"property_loss"`
+	ContentsLoss     int       `json:"contents_loss"`
+	ValueSaved       int       `json:"value_saved"`
+	DetectorsPresent string    `json:"detectors_present"` // picklist "detector_presence"
+	Notes            string    `json:"notes"`
+	UpdatedAt        time.Time `json:"updated_at"`
+}
+
+// TotalLoss returns the combined property and contents loss
+func (f FireDetails) TotalLoss() int {
+	return f.PropertyLoss + f.ContentsLoss
+}
+
+// FireLossTotals summarizes fire losses over a reporting period
+type FireLossTotals struct {
+	Fires        int `json:"fires"`
+	PropertyLoss int `json:"property_loss"`
+	ContentsLoss int `json:"contents_loss"`
+	ValueSaved   int `json:"value_saved"`
+}
+
+// TotalLoss returns the combined property and contents loss
+func (t FireLossTotals) TotalLoss() int {
+	return t.PropertyLoss + t.ContentsLoss
+}
+
+// InventoryItem is a consumable or piece of equipment in the usage catalog
+type InventoryItem struct {
+	ID            int    `json:"id"`
+	Name          string `json:"name"`
+	Category      string `json:"category"` // picklist "inventory_category"
+	Unit          string `json:"unit"`     // e.g. "gallon", "bag", "fill"
+	UnitCostCents int    `json:"unit_cost_cents"`
+	Active        bool   `json:"active"`
+	SortOrder     int    `json:"sort_order"`
+}
+
+// InventoryUsage is a quantity of an inventory item used on a call
+type InventoryUsage struct {
+	ID       int     `json:"id"`
+	CallID   int     `json:"call_id"`
+	ItemID   int     `json:"item_id"`
+	ItemName string  `json:"item_name"`
+	Unit     string  `json:"unit"`
+	Quantity float64 `json:"quantity"`
+	Notes    string  `json:"notes"`
+}
+
+// InventoryUsageTotal is the total use of one item over a reporting period
+type InventoryUsageTotal struct {
+	ItemID         int     `json:"item_id"`
+	ItemName       string  `json:"item_name"`
+	Category       string  `json:"category"`
+	Unit           string  `json:"unit"`
+	Quantity       float64 `json:"quantity"`
+	Calls          int     `json:"calls"`
+	TotalCostCents int     `json:"total_cost_cents"`
+}
+
+// NarrativeTemplate is an admin-managed narrative for a call type. The body
+// may contain placeholders such as {address}, {apparatus}, {responders} and
+// {dispatched} that are filled from the call being entered.
+type NarrativeTemplate struct {
+	ID        int    `json:"id"`
+	CallType  string `json:"call_type"`
+	Name      string `json:"name"`
+	Body      string `json:"body"`
+	SortOrder int    `json:"sort_order"`
+	Active    bool   `json:"active"`
+}
+
+// Attachment represents a photo or document attached to a call. Data is
+// only populated when a single attachment is downloaded.
+type Attachment struct {
+	ID         int       `json:"id"`
+	CallID     int       `json:"call_id"`
+	FileName   string    `json:"file_name"`
+	MimeType   string    `json:"mime_type"`
+	Size       int       `json:"size"`
+	SHA256     string    `json:"sha256"`
+	Caption    string    `json:"caption"`
+	UploadedBy int       `json:"uploaded_by"`
+	UploadedAt time.Time `json:"uploaded_at"`
+	Data       []byte    `json:"data,omitempty"`
+}
+
+// Event is a training, drill, meeting or detail. Events are numbered and
+// stored apart from emergency calls so they never count toward call statistics.
+type Event struct {
+	ID          int       `json:"id"`
+	EventNumber string    `json:"event_number"` // e.g. T2026-001
+	EventType   string    `json:"event_type"`   // picklist "event_type"
+	Topic       string    `json:"topic"`
+	Instructor  string    `json:"instructor"`
+	Location    string    `json:"location"`
+	StartTime   time.Time `json:"start_time"`
+	Hours       float64   `json:"hours"`
+	Notes       string    `json:"notes"`
+	CreatedBy   int       `json:"created_by"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+	Attendees   []User    `json:"attendees,omitempty"` // populated by GetEventByID
+}
+
+// EventFilter narrows an event list. Zero-valued fields are ignored.
+type EventFilter struct {
+	Text       string     `json:"text"` // number, topic or instructor contains
+	StartFrom  *time.Time `json:"start_from"`
+	StartTo    *time.Time `json:"start_to"`
+	EventType  string     `json:"event_type"`
+	AttendeeID int        `json:"attendee_id"` // attended by this member
+}
+
 // Setting represents application configuration
 type Setting struct {
 	Key   string `json:"key"`
@@ -88,6 +442,153 @@ type AuditLog struct {
 	Timestamp time.Time `json:"timestamp"`
 }
 
+// BulkResult describes the calls a bulk operation changed, or would change
+// when run as a dry run
+type BulkResult struct {
+	DryRun   bool   `json:"dry_run"`
+	Affected int    `json:"affected"`
+	Calls    []Call `json:"calls"`
+}
+
+// CallFilter selects calls for searches, exports and bulk changes. Zero
+// values leave a criterion out, so the zero filter matches every call.
+type CallFilter struct {
+	// Text matches the incident number, or full-text searches the narrative,
+	// address, location notes and town (see SearchCallText)
+	Text string `json:"text"`
+
+	// DispatchedFrom and DispatchedTo bound the dispatch time (inclusive)
+	DispatchedFrom *time.Time `json:"dispatched_from"`
+	DispatchedTo   *time.Time `json:"dispatched_to"`
+
+	CallTypes []string `json:"call_types"`
+	Towns     []string `json:"towns"` // matched ignoring case
+	// AddressKey matches calls at one premise (see NormalizeAddress)
+	AddressKey string `json:"address_key"`
+	// MutualAid matches any of the listed values, e.g. MutualAidGiven
+	MutualAid []string `json:"mutual_aid"`
+
+	// ResponderIDs and ApparatusIDs match calls with any of the listed
+	// members or units, or all of them when AllResponders/AllApparatus is set
+	ResponderIDs  []int `json:"responder_ids"`
+	AllResponders bool  `json:"all_responders"`
+	ApparatusIDs  []int `json:"apparatus_ids"`
+	AllApparatus  bool  `json:"all_apparatus"`
+
+	HasClearTime *bool `json:"has_clear_time"`
+	// Status is one of the CallStatus values, derived from the timeline
+	Status string `json:"status"`
+
+	Wind            string `json:"wind"`
+	Precipitation   string `json:"precipitation"`
+	RoadConditions  string `json:"road_conditions"`
+	MinTemperatureF *int   `json:"min_temperature_f"`
+	MaxTemperatureF *int   `json:"max_temperature_f"`
+
+	// Exclude drops calls matching any of these filters; their Sort is ignored
+	Exclude []CallFilter `json:"exclude"`
+
+	// Sort is one of the CallSort values; empty sorts newest dispatch first
+	Sort string `json:"sort"`
+}
+
+// CallSearchResult is a call found by full-text search. Rank orders
+// results (lower is a better match) and Snippet is the best-matching
+// passage with matched terms between SnippetMatchStart and SnippetMatchEnd.
+type CallSearchResult struct {
+	Call    Call    `json:"call"`
+	Rank    float64 `json:"rank"`
+	Snippet string  `json:"snippet"`
+}
+
+// CallMatch is a call found by a responder or apparatus filter, with the
+// filtered members and units that were on it
+type CallMatch struct {
+	Call              Call        `json:"call"`
+	MatchedResponders []User      `json:"matched_responders"`
+	MatchedApparatus  []Apparatus `json:"matched_apparatus"`
+}
+
+// SavedFilter is a named CallFilter, visible to its owner or, when shared,
+// to the whole department
+type SavedFilter struct {
+	ID        int        `json:"id"`
+	Name      string     `json:"name"`
+	OwnerID   int        `json:"owner_id"`
+	Shared    bool       `json:"shared"`
+	Filter    CallFilter `json:"filter"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+}
+
+// CallPage is one page of a call list. Total counts every matching call;
+// pass NextCursor back to get the following page, which is empty on the
+// last page.
+type CallPage struct {
+	Items      []Call `json:"items"`
+	Total      int    `json:"total"`
+	NextCursor string `json:"next_cursor"`
+}
+
+// CallMatchPage is one page of SearchCallMatches results (see CallPage)
+type CallMatchPage struct {
+	Items      []CallMatch `json:"items"`
+	Total      int         `json:"total"`
+	NextCursor string      `json:"next_cursor"`
+}
+
+// CallSearchPage is one page of full-text search results (see CallPage)
+type CallSearchPage struct {
+	Items      []CallSearchResult `json:"items"`
+	Total      int                `json:"total"`
+	NextCursor string             `json:"next_cursor"`
+}
+
+// EventPage is one page of an event list (see CallPage)
+type EventPage struct {
+	Items      []Event `json:"items"`
+	Total      int     `json:"total"`
+	NextCursor string  `json:"next_cursor"`
+}
+
+// AuditLogPage is one page of the audit log (see CallPage)
+type AuditLogPage struct {
+	Items      []AuditLog `json:"items"`
+	Total      int        `json:"total"`
+	NextCursor string     `json:"next_cursor"`
+}
+
+// ApparatusUsagePage is one page of a unit's usage history (see CallPage)
+type ApparatusUsagePage struct {
+	Items      []ApparatusUsage `json:"items"`
+	Total      int              `json:"total"`
+	NextCursor string           `json:"next_cursor"`
+}
+
+// Mutual aid values seeded in the "mutual_aid" picklist
+const (
+	MutualAidNone     = "None"
+	MutualAidGiven    = "Given"
+	MutualAidReceived = "Received"
+)
+
+// Call statuses, from the latest timeline entry a call has
+const (
+	CallStatusDispatched = "dispatched"
+	CallStatusEnroute    = "enroute"
+	CallStatusOnScene    = "on_scene"
+	CallStatusCleared    = "cleared"
+)
+
+// Call sort orders
+const (
+	CallSortDispatchedDesc = "dispatched_desc"
+	CallSortDispatchedAsc  = "dispatched_asc"
+	CallSortIncidentNumber = "incident_number"
+	CallSortCallType       = "call_type"
+	CallSortTown           = "town"
+)
+
 // Logo represents the uploaded logo image
 type Logo struct {
 	ID         int       `json:"id"`
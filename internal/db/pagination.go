@@ -0,0 +1,207 @@
+package db
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Page sizes for list methods. A limit of 0 or less uses defaultPageSize.
+// Lists that grow with use (calls, events, audit entries, usage readings)
+// are returned a page at a time; small reference lists such as users,
+// picklists and apparatus are returned whole.
+const (
+	defaultPageSize = 100
+	maxPageSize     = 1000
+)
+
+// pageSize clamps a requested page size to [1, maxPageSize]
+func pageSize(limit int) int {
+	if limit <= 0 {
+		return defaultPageSize
+	}
+	if limit > maxPageSize {
+		return maxPageSize
+	}
+	return limit
+}
+
+// pageCursor is a decoded page cursor. Lists in dispatch order page by the
+// last call already returned ("after:<id>"), so calls added between pages,
+// or removed other than the cursor's own call, never cause skips or
+// repeats. If the cursor's call is removed the next page fails and the
+// list must be restarted. Other orders page by offset ("offset:<n>").
+type pageCursor struct {
+	afterID int
+	offset  int
+}
+
+// parseCursor decodes a cursor from a previous page; "" is the first page
+func parseCursor(cursor string) (pageCursor, error) {
+	if cursor == "" {
+		return pageCursor{}, nil
+	}
+	kind, value, _ := strings.Cut(cursor, ":")
+	n, err := strconv.Atoi(value)
+	if err != nil || n <= 0 {
+		return pageCursor{}, fmt.Errorf("invalid page cursor %q", cursor)
+	}
+	switch kind {
+	case "after":
+		return pageCursor{afterID: n}, nil
+	case "offset":
+		return pageCursor{offset: n}, nil
+	}
+	return pageCursor{}, fmt.Errorf("invalid page cursor %q", cursor)
+}
+
+// keysetCursor decodes the cursor of a list that pages by its last row
+func keysetCursor(cursor string) (int, error) {
+	position, err := parseCursor(cursor)
+	if err != nil {
+		return 0, err
+	}
+	if position.offset != 0 {
+		return 0, errors.New("page cursor does not match the sort order")
+	}
+	return position.afterID, nil
+}
+
+// offsetCursor decodes the cursor of a list that pages by offset
+func offsetCursor(cursor string) (int, error) {
+	position, err := parseCursor(cursor)
+	if err != nil {
+		return 0, err
+	}
+	if position.afterID != 0 {
+		return 0, errors.New("page cursor does not match the sort order")
+	}
+	return position.offset, nil
+}
+
+// afterRow returns the condition that continues a list ordered by
+// (column, id) past the row of table with id afterID. compare is "<" for
+// lists in descending order and ">" for ascending ones.
+func (db *DB) afterRow(table, column, compare string, afterID int) (string, error) {
+	var exists bool
+	if err := db.QueryRow("SELECT EXISTS(SELECT 1 FROM "+table+" WHERE id = ?)", afterID).Scan(&exists); err != nil {
+		return "", err
+	}
+	if !exists {
+		return "", errors.New("page cursor is no longer valid; start from the first page")
+	}
+	// Row values compare the stored text exactly, with id breaking ties
+	// between rows with the same key
+	return " AND (" + column + ", id) " + compare + " (SELECT " + column + ", id FROM " + table + " WHERE id = ?)", nil
+}
+
+// trimPage cuts a list fetched with limit+1 rows back to limit, reporting
+// whether there was another page
+func trimPage[T any](items []T, limit int) ([]T, bool) {
+	if len(items) > limit {
+		return items[:limit], true
+	}
+	return items, false
+}
+
+// keysetSorts are the sort orders that page by the last call's
+// (dispatched, id), with the comparison that moves forward in each
+var keysetSorts = map[string]string{
+	"":                     "<",
+	CallSortDispatchedDesc: "<",
+	CallSortDispatchedAsc:  ">",
+}
+
+// SearchCallsPage returns one page of the calls matching filter, in the
+// filter's sort order. Pass "" as cursor for the first page.
+func (db *DB) SearchCallsPage(filter CallFilter, cursor string, limit int) (*CallPage, error) {
+	where, args, err := filter.where()
+	if err != nil {
+		return nil, err
+	}
+	orderBy, err := filter.orderBy()
+	if err != nil {
+		return nil, err
+	}
+	position, err := parseCursor(cursor)
+	if err != nil {
+		return nil, err
+	}
+	limit = pageSize(limit)
+
+	page := &CallPage{}
+	if err := db.QueryRow("SELECT COUNT(*) FROM calls WHERE 1=1"+where, args...).Scan(&page.Total); err != nil {
+		return nil, err
+	}
+
+	compare, keyset := keysetSorts[filter.Sort]
+	if position.afterID != 0 {
+		if !keyset {
+			return nil, errors.New("page cursor does not match the sort order")
+		}
+		after, err := db.afterRow("calls", "dispatched", compare, position.afterID)
+		if err != nil {
+			return nil, err
+		}
+		where += after
+		args = append(args, position.afterID)
+	} else if position.offset != 0 && keyset {
+		return nil, errors.New("page cursor does not match the sort order")
+	}
+
+	query := `
+		SELECT ` + callColumns + `
+		FROM calls
+		WHERE 1=1
+	` + where + " ORDER BY " + orderBy + " LIMIT ? OFFSET ?"
+	args = append(args, limit+1, position.offset)
+
+	rows, err := db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var call Call
+		if err := scanCall(rows, &call); err != nil {
+			return nil, err
+		}
+		page.Items = append(page.Items, call)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	var more bool
+	if page.Items, more = trimPage(page.Items, limit); more {
+		if keyset {
+			page.NextCursor = fmt.Sprintf("after:%d", page.Items[limit-1].ID)
+		} else {
+			page.NextCursor = fmt.Sprintf("offset:%d", position.offset+limit)
+		}
+	}
+	return page, nil
+}
+
+// ForEachCallPage calls fn with each page of the calls matching filter
+// until every call has been seen, so exports never hold more than one page
+func (db *DB) ForEachCallPage(filter CallFilter, limit int, fn func(calls []Call) error) error {
+	cursor := ""
+	for {
+		page, err := db.SearchCallsPage(filter, cursor, limit)
+		if err != nil {
+			return err
+		}
+		if len(page.Items) > 0 {
+			if err := fn(page.Items); err != nil {
+				return err
+			}
+		}
+		if page.NextCursor == "" {
+			return nil
+		}
+		cursor = page.NextCursor
+	}
+}
@@ -0,0 +1,152 @@
+package db
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestSearchCallsPageCursor(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	base := time.Date(2026, 4, 1, 10, 0, 0, 0, time.UTC)
+	var want []int
+	for i := 0; i < 5; i++ {
+		// Two calls share each dispatch time, so id has to break ties
+		call := createTestCall(t, db, "Rescue", "1 Elm St", base.Add(time.Duration(i/2)*time.Hour))
+		want = append([]int{call.ID}, want...)
+	}
+
+	var got []int
+	cursor := ""
+	for pages := 0; ; pages++ {
+		page, err := db.SearchCallsPage(CallFilter{}, cursor, 2)
+		if err != nil {
+			t.Fatalf("Failed to get page: %v", err)
+		}
+		if pages == 0 && page.Total != 5 {
+			t.Errorf("Expected total of 5, got %d", page.Total)
+		}
+		for _, call := range page.Items {
+			got = append(got, call.ID)
+		}
+		if pages == 0 {
+			// A call logged while paging must not shift later pages
+			createTestCall(t, db, "Rescue", "2 Elm St", base.Add(24*time.Hour))
+		}
+		if page.NextCursor == "" {
+			break
+		}
+		cursor = page.NextCursor
+	}
+
+	if len(got) != len(want) {
+		t.Fatalf("Expected calls %v, got %v", want, got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("Expected calls %v, got %v", want, got)
+		}
+	}
+}
+
+func TestSearchCallsPageOffsetAndErrors(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	for i := 0; i < 3; i++ {
+		createTestCall(t, db, "Rescue", "1 Elm St", time.Now().Add(time.Duration(i)*time.Minute))
+	}
+
+	seen := 0
+	err := db.ForEachCallPage(CallFilter{Sort: CallSortTown}, 2, func(calls []Call) error {
+		seen += len(calls)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Failed to walk pages: %v", err)
+	}
+	if seen != 3 {
+		t.Errorf("Expected to see 3 calls across pages, got %d", seen)
+	}
+
+	for _, cursor := range []string{"bogus", "after:x", "offset:-1"} {
+		if _, err := db.SearchCallsPage(CallFilter{}, cursor, 10); err == nil {
+			t.Errorf("Expected error for cursor %q", cursor)
+		}
+	}
+	if _, err := db.SearchCallsPage(CallFilter{Sort: CallSortTown}, "after:1", 10); err == nil {
+		t.Error("Expected error for keyset cursor on a town sort")
+	}
+	if _, err := db.SearchCallsPage(CallFilter{}, "after:9999", 10); err == nil {
+		t.Error("Expected error for cursor of a deleted call")
+	}
+}
+
+func TestEventAndAuditPages(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	base := time.Date(2026, 2, 1, 19, 0, 0, 0, time.Local)
+	for i := 0; i < 5; i++ {
+		// Two drills share a start time so paging must break the tie by id
+		start := base.AddDate(0, 0, i/2)
+		event := &Event{EventType: "Drill", Topic: fmt.Sprintf("Drill %d", i), StartTime: start, Hours: 2}
+		if err := db.CreateEvent(event, nil); err != nil {
+			t.Fatalf("Failed to create event: %v", err)
+		}
+	}
+
+	seen := make(map[int]bool)
+	cursor := ""
+	for pages := 0; ; pages++ {
+		page, err := db.GetRecentEvents(cursor, 2)
+		if err != nil {
+			t.Fatalf("Failed to get events: %v", err)
+		}
+		if page.Total != 5 {
+			t.Errorf("Expected total 5, got %d", page.Total)
+		}
+		for _, event := range page.Items {
+			if seen[event.ID] {
+				t.Errorf("Event %d returned twice", event.ID)
+			}
+			seen[event.ID] = true
+		}
+		if page.NextCursor == "" {
+			break
+		}
+		if pages > 5 {
+			t.Fatal("Paging did not finish")
+		}
+		cursor = page.NextCursor
+	}
+	if len(seen) != 5 {
+		t.Errorf("Expected all 5 events across pages, got %d", len(seen))
+	}
+	if _, err := db.GetRecentEvents("offset:2", 2); err == nil {
+		t.Error("Expected an offset cursor to be rejected for events")
+	}
+
+	for i := 0; i < 3; i++ {
+		createTestCall(t, db, "Rescue", fmt.Sprintf("%d River Rd", i), base)
+		if _, err := db.BulkSetCallField(CallFilter{CallTypes: []string{"Rescue"}}, "town", "Readsboro", 1, false); err != nil {
+			t.Fatalf("Failed to update calls: %v", err)
+		}
+	}
+	first, err := db.GetAuditLog("", 2)
+	if err != nil {
+		t.Fatalf("Failed to get audit log: %v", err)
+	}
+	if first.Total != 3 || len(first.Items) != 2 || first.NextCursor == "" {
+		t.Fatalf("Expected 2 of 3 audit entries, got %d of %d", len(first.Items), first.Total)
+	}
+	rest, err := db.GetAuditLog(first.NextCursor, 2)
+	if err != nil {
+		t.Fatalf("Failed to get audit log page: %v", err)
+	}
+	if len(rest.Items) != 1 || rest.NextCursor != "" || rest.Items[0].ID >= first.Items[1].ID {
+		t.Errorf("Expected the oldest entry on the last page, got %+v", rest.Items)
+	}
+}
@@ -0,0 +1,61 @@
+package db
+
+import (
+	"reflect"
+)
+
+// RedactPII blanks every string field tagged pii:"true" in the struct v
+// points to, or in each element of the slice it points to. It is used to
+// strip personal information from exports for users who may not see it.
+func RedactPII(v interface{}) {
+	redactValue(reflect.ValueOf(v))
+}
+
+func redactValue(v reflect.Value) {
+	switch v.Kind() {
+	case reflect.Ptr:
+		if !v.IsNil() {
+			redactValue(v.Elem())
+		}
+	case reflect.Slice:
+		for i := 0; i < v.Len(); i++ {
+			redactValue(v.Index(i))
+		}
+	case reflect.Map:
+		for _, key := range v.MapKeys() {
+			elem := v.MapIndex(key)
+			if elem.Kind() == reflect.Slice {
+				redactValue(elem)
+			}
+		}
+	case reflect.Struct:
+		t := v.Type()
+		for i := 0; i < t.NumField(); i++ {
+			field := v.Field(i)
+			if !field.CanSet() {
+				continue
+			}
+			if t.Field(i).Tag.Get("pii") == "true" && field.Kind() == reflect.String {
+				field.SetString("")
+				continue
+			}
+			redactValue(field)
+		}
+	}
+}
+
+// PIIFields returns the JSON names of the fields tagged pii:"true" on the
+// struct type of v, so the UI can mark them
+func PIIFields(v interface{}) []string {
+	t := reflect.TypeOf(v)
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	var fields []string
+	for i := 0; i < t.NumField(); i++ {
+		if t.Field(i).Tag.Get("pii") == "true" {
+			fields = append(fields, t.Field(i).Tag.Get("json"))
+		}
+	}
+	return fields
+}
@@ -0,0 +1,192 @@
+package db
+
+import (
+	"database/sql"
+	"errors"
+	"strings"
+)
+
+// apparatusTypes seeds the "apparatus_type" picklist
+var apparatusTypes = []string{"Engine", "Ladder/Truck", "Rescue", "Ambulance", "Tanker", "Brush", "Command", "Utility"}
+
+// ensureApparatusTable moves apparatus that older databases kept as
+// "apparatus" picklist rows into the apparatus table. Units keep their IDs
+// so existing call_apparatus rows stay valid, and call_apparatus is rebuilt
+// so its foreign key points at apparatus instead of picklists.
+func (db *DB) ensureApparatusTable() error {
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.Exec(`
+		INSERT INTO apparatus (id, name, sort_order, active)
+		SELECT id, value, sort_order, active FROM picklists
+		WHERE category = 'apparatus' AND id NOT IN (SELECT id FROM apparatus)
+	`)
+	if err != nil {
+		return err
+	}
+
+	var legacy int
+	err = tx.QueryRow(`
+		SELECT COUNT(*) FROM pragma_foreign_key_list('call_apparatus') WHERE "table" = 'picklists'
+	`).Scan(&legacy)
+	if err != nil {
+		return err
+	}
+
+	if legacy > 0 {
+		migration := `
+		CREATE TABLE call_apparatus_new (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			call_id INTEGER NOT NULL,
+			apparatus_id INTEGER NOT NULL,
+			FOREIGN KEY(call_id) REFERENCES calls(id) ON DELETE CASCADE,
+			FOREIGN KEY(apparatus_id) REFERENCES apparatus(id),
+			UNIQUE(call_id, apparatus_id)
+		);
+		INSERT INTO call_apparatus_new (id, call_id, apparatus_id)
+			SELECT id, call_id, apparatus_id FROM call_apparatus;
+		DROP TABLE call_apparatus;
+		ALTER TABLE call_apparatus_new RENAME TO call_apparatus;
+		`
+		if _, err := tx.Exec(migration); err != nil {
+			return err
+		}
+	}
+
+	if _, err := tx.Exec("DELETE FROM picklists WHERE category = 'apparatus'"); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// apparatusColumns is the column list read by scanApparatus
+const apparatusColumns = `id, name, unit_type, seats, pump_gpm, station, in_service, active, sort_order, created_at`
+
+// scanApparatus scans a row selected with apparatusColumns into unit
+func scanApparatus(row rowScanner, unit *Apparatus) error {
+	return row.Scan(&unit.ID, &unit.Name, &unit.UnitType, &unit.Seats, &unit.PumpGPM,
+		&unit.Station, &unit.InService, &unit.Active, &unit.SortOrder, &unit.CreatedAt)
+}
+
+// GetAllApparatus returns every unit, including retired ones (for admin)
+func (db *DB) GetAllApparatus() ([]Apparatus, error) {
+	return db.queryApparatus(`
+		SELECT ` + apparatusColumns + `
+		FROM apparatus
+		ORDER BY sort_order, name
+	`)
+}
+
+// GetActiveApparatus returns the units that have not been retired
+func (db *DB) GetActiveApparatus() ([]Apparatus, error) {
+	return db.queryApparatus(`
+		SELECT ` + apparatusColumns + `
+		FROM apparatus
+		WHERE active = 1
+		ORDER BY sort_order, name
+	`)
+}
+
+// queryApparatus runs an apparatus query and scans the results
+func (db *DB) queryApparatus(query string, args ...interface{}) ([]Apparatus, error) {
+	rows, err := db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var units []Apparatus
+	for rows.Next() {
+		var unit Apparatus
+		if err := scanApparatus(rows, &unit); err != nil {
+			return nil, err
+		}
+		units = append(units, unit)
+	}
+	return units, rows.Err()
+}
+
+// GetApparatus returns a single unit by ID, or nil if it does not exist
+func (db *DB) GetApparatus(id int) (*Apparatus, error) {
+	var unit Apparatus
+	err := scanApparatus(db.QueryRow(`
+		SELECT `+apparatusColumns+`
+		FROM apparatus WHERE id = ?
+	`, id), &unit)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &unit, nil
+}
+
+// validateApparatus checks a unit's fields before it is saved
+func (db *DB) validateApparatus(unit *Apparatus) error {
+	unit.Name = strings.TrimSpace(unit.Name)
+	if unit.Name == "" {
+		return errors.New("apparatus name is required")
+	}
+	if unit.Seats < 0 || unit.PumpGPM < 0 {
+		return errors.New("seats and pump capacity cannot be negative")
+	}
+	return db.validatePicklistValue("apparatus_type", unit.UnitType)
+}
+
+// CreateApparatus adds a new in-service unit
+func (db *DB) CreateApparatus(unit *Apparatus) error {
+	if err := db.validateApparatus(unit); err != nil {
+		return err
+	}
+
+	unit.InService = true
+	unit.Active = true
+	result, err := db.Exec(`
+		INSERT INTO apparatus (name, unit_type, seats, pump_gpm, station, in_service, active, sort_order)
+		VALUES (?, ?, ?, ?, ?, 1, 1, ?)
+	`, unit.Name, unit.UnitType, unit.Seats, unit.PumpGPM, unit.Station, unit.SortOrder)
+	if err != nil {
+		return err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+	unit.ID = int(id)
+	return nil
+}
+
+// UpdateApparatus updates a unit's attributes and status
+func (db *DB) UpdateApparatus(unit *Apparatus) error {
+	if err := db.validateApparatus(unit); err != nil {
+		return err
+	}
+
+	_, err := db.Exec(`
+		UPDATE apparatus
+		SET name = ?, unit_type = ?, seats = ?, pump_gpm = ?, station = ?,
+			in_service = ?, active = ?, sort_order = ?
+		WHERE id = ?
+	`, unit.Name, unit.UnitType, unit.Seats, unit.PumpGPM, unit.Station,
+		unit.InService, unit.Active, unit.SortOrder, unit.ID)
+	return err
+}
+
+// SetApparatusInService puts a unit in or out of service
+func (db *DB) SetApparatusInService(id int, inService bool) error {
+	_, err := db.Exec("UPDATE apparatus SET in_service = ? WHERE id = ?", inService, id)
+	return err
+}
+
+// DeleteApparatus retires a unit (sets active = false). Units stay in the
+// table so calls they responded to keep their history.
+func (db *DB) DeleteApparatus(id int) error {
+	_, err := db.Exec("UPDATE apparatus SET active = 0 WHERE id = ?", id)
+	return err
+}
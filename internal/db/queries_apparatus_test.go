@@ -0,0 +1,97 @@
+package db
+
+import (
+	"testing"
+	"time"
+)
+
+func TestApparatusMigratedFromPicklists(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	// Recreate the layout of a database from before the apparatus table
+	legacy := `
+	DROP TABLE call_apparatus;
+	CREATE TABLE call_apparatus (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		call_id INTEGER NOT NULL,
+		apparatus_id INTEGER NOT NULL,
+		FOREIGN KEY(call_id) REFERENCES calls(id) ON DELETE CASCADE,
+		FOREIGN KEY(apparatus_id) REFERENCES picklists(id),
+		UNIQUE(call_id, apparatus_id)
+	);
+	INSERT INTO picklists (id, category, value, sort_order, active) VALUES (900, 'apparatus', 'Engine 9', 1, 1);
+	`
+	if _, err := db.Exec(legacy); err != nil {
+		t.Fatalf("Failed to create legacy layout: %v", err)
+	}
+	call := createTestCall(t, db, "Structure Fire", "4 Mill St", time.Now())
+	if _, err := db.Exec("INSERT INTO call_apparatus (call_id, apparatus_id) VALUES (?, 900)", call.ID); err != nil {
+		t.Fatalf("Failed to assign legacy apparatus: %v", err)
+	}
+
+	if err := db.ensureApparatusTable(); err != nil {
+		t.Fatalf("Failed to migrate apparatus: %v", err)
+	}
+
+	_, apparatus, _, err := db.GetCallByID(call.ID)
+	if err != nil {
+		t.Fatalf("Failed to get call: %v", err)
+	}
+	if len(apparatus) != 1 || apparatus[0].ID != 900 || apparatus[0].Name != "Engine 9" {
+		t.Errorf("Expected call to keep Engine 9 after migration, got %+v", apparatus)
+	}
+
+	items, err := db.GetPicklistByCategory("apparatus")
+	if err != nil {
+		t.Fatalf("Failed to get picklist: %v", err)
+	}
+	if len(items) != 0 {
+		t.Errorf("Expected apparatus picklist rows to be removed, got %d", len(items))
+	}
+
+	// Running the migration again is a no-op
+	if err := db.ensureApparatusTable(); err != nil {
+		t.Fatalf("Second migration failed: %v", err)
+	}
+}
+
+func TestApparatusCRUD(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	unit := &Apparatus{Name: "Brush 1", UnitType: "Brush", Seats: 2, PumpGPM: 250, Station: "Station 2"}
+	if err := db.CreateApparatus(unit); err != nil {
+		t.Fatalf("Failed to create apparatus: %v", err)
+	}
+	if err := db.CreateApparatus(&Apparatus{Name: "Boat 1", UnitType: "Hovercraft"}); err == nil {
+		t.Error("Expected error for unit type not in picklist")
+	}
+	if err := db.CreatePicklistItem("apparatus", "Engine 9", 9); err == nil {
+		t.Error("Expected error adding apparatus as a picklist item")
+	}
+
+	if err := db.SetApparatusInService(unit.ID, false); err != nil {
+		t.Fatalf("Failed to set out of service: %v", err)
+	}
+	saved, err := db.GetApparatus(unit.ID)
+	if err != nil || saved == nil {
+		t.Fatalf("Failed to get apparatus: %v", err)
+	}
+	if saved.InService || saved.PumpGPM != 250 {
+		t.Errorf("Unexpected apparatus state: %+v", saved)
+	}
+
+	if err := db.DeleteApparatus(unit.ID); err != nil {
+		t.Fatalf("Failed to retire apparatus: %v", err)
+	}
+	active, err := db.GetActiveApparatus()
+	if err != nil {
+		t.Fatalf("Failed to list apparatus: %v", err)
+	}
+	for _, a := range active {
+		if a.ID == unit.ID {
+			t.Error("Retired unit should not be listed as active")
+		}
+	}
+}
@@ -0,0 +1,339 @@
+package db
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// usageReading is one start/end pair of an apparatus usage record
+type usageReading struct {
+	label    string
+	startCol string
+	endCol   string
+	start    *float64
+	end      *float64
+}
+
+// readings returns the start/end pairs of a usage record
+func (u *ApparatusUsage) readings() []usageReading {
+	return []usageReading{
+		{"mileage", "start_mileage", "end_mileage", u.StartMileage, u.EndMileage},
+		{"engine hours", "start_engine_hours", "end_engine_hours", u.StartEngineHours, u.EndEngineHours},
+		{"pump hours", "start_pump_hours", "end_pump_hours", u.StartPumpHours, u.EndPumpHours},
+	}
+}
+
+// validateUsage checks that a unit's readings only go forward: end after
+// start, start after the unit's reading from its previous call, and end
+// before its reading from any later call already entered. Calls are
+// ordered by (dispatched, id), so calls dispatched at the same time are
+// still checked against each other.
+func (db *DB) validateUsage(usage *ApparatusUsage) error {
+	var dispatched time.Time
+	err := db.QueryRow(`
+		SELECT c.dispatched FROM call_apparatus ca
+		JOIN calls c ON ca.call_id = c.id
+		WHERE ca.call_id = ? AND ca.apparatus_id = ?
+	`, usage.CallID, usage.ApparatusID).Scan(&dispatched)
+	if err == sql.ErrNoRows {
+		return errors.New("apparatus is not assigned to this call")
+	}
+	if err != nil {
+		return err
+	}
+
+	for _, r := range usage.readings() {
+		if (r.start != nil && *r.start < 0) || (r.end != nil && *r.end < 0) {
+			return fmt.Errorf("%s cannot be negative", r.label)
+		}
+		if r.start != nil && r.end != nil && *r.end < *r.start {
+			return fmt.Errorf("ending %s cannot be less than starting %s", r.label, r.label)
+		}
+
+		var previous sql.NullFloat64
+		err := db.QueryRow(`
+			SELECT MAX(COALESCE(u.`+r.endCol+`, u.`+r.startCol+`))
+			FROM apparatus_usage u
+			JOIN calls c ON u.call_id = c.id
+			WHERE u.apparatus_id = ? AND (c.dispatched, c.id) < (?, ?)
+		`, usage.ApparatusID, dispatched, usage.CallID).Scan(&previous)
+		if err != nil {
+			return err
+		}
+		first := r.start
+		if first == nil {
+			first = r.end
+		}
+		if first != nil && previous.Valid && *first < previous.Float64 {
+			return fmt.Errorf("%s %.1f is less than the previous reading of %.1f", r.label, *first, previous.Float64)
+		}
+
+		var next sql.NullFloat64
+		err = db.QueryRow(`
+			SELECT MIN(COALESCE(u.`+r.startCol+`, u.`+r.endCol+`))
+			FROM apparatus_usage u
+			JOIN calls c ON u.call_id = c.id
+			WHERE u.apparatus_id = ? AND (c.dispatched, c.id) > (?, ?)
+		`, usage.ApparatusID, dispatched, usage.CallID).Scan(&next)
+		if err != nil {
+			return err
+		}
+		last := r.end
+		if last == nil {
+			last = r.start
+		}
+		if last != nil && next.Valid && *last > next.Float64 {
+			return fmt.Errorf("%s %.1f is more than the reading of %.1f on a later call", r.label, *last, next.Float64)
+		}
+	}
+	return nil
+}
+
+// SaveApparatusUsage creates or replaces a unit's readings for a call
+func (db *DB) SaveApparatusUsage(usage *ApparatusUsage) error {
+	if err := db.validateUsage(usage); err != nil {
+		return err
+	}
+
+	var recordedBy interface{}
+	if usage.RecordedBy > 0 {
+		recordedBy = usage.RecordedBy
+	}
+
+	_, err := db.Exec(`
+		INSERT INTO apparatus_usage (
+			call_id, apparatus_id, start_mileage, end_mileage,
+			start_engine_hours, end_engine_hours, start_pump_hours, end_pump_hours,
+			recorded_by, recorded_at
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, CURRENT_TIMESTAMP)
+		ON CONFLICT(call_id, apparatus_id) DO UPDATE SET
+			start_mileage = excluded.start_mileage,
+			end_mileage = excluded.end_mileage,
+			start_engine_hours = excluded.start_engine_hours,
+			end_engine_hours = excluded.end_engine_hours,
+			start_pump_hours = excluded.start_pump_hours,
+			end_pump_hours = excluded.end_pump_hours,
+			recorded_by = excluded.recorded_by,
+			recorded_at = CURRENT_TIMESTAMP
+	`, usage.CallID, usage.ApparatusID, usage.StartMileage, usage.EndMileage,
+		usage.StartEngineHours, usage.EndEngineHours, usage.StartPumpHours, usage.EndPumpHours,
+		recordedBy)
+	return err
+}
+
+// deleteUnassignedUsage removes a call's readings for units that are no
+// longer on it, so they stop counting toward maintenance and later checks
+func deleteUnassignedUsage(tx *sql.Tx, callID int) error {
+	_, err := tx.Exec(`
+		DELETE FROM apparatus_usage
+		WHERE call_id = ? AND apparatus_id NOT IN (SELECT apparatus_id FROM call_apparatus WHERE call_id = ?)
+	`, callID, callID)
+	return err
+}
+
+// ensureUsageAssigned removes readings left behind by units taken off calls
+// before their readings were removed with them (migration for existing databases)
+func (db *DB) ensureUsageAssigned() error {
+	_, err := db.Exec(`
+		DELETE FROM apparatus_usage
+		WHERE NOT EXISTS (
+			SELECT 1 FROM call_apparatus ca
+			WHERE ca.call_id = apparatus_usage.call_id AND ca.apparatus_id = apparatus_usage.apparatus_id
+		)
+	`)
+	return err
+}
+
+// DeleteApparatusUsage removes a unit's readings for a call
+func (db *DB) DeleteApparatusUsage(callID, apparatusID int) error {
+	_, err := db.Exec("DELETE FROM apparatus_usage WHERE call_id = ? AND apparatus_id = ?", callID, apparatusID)
+	return err
+}
+
+// usageSelect selects usage rows with the unit name and call details
+const usageSelect = `
+	SELECT u.id, u.call_id, u.apparatus_id, u.start_mileage, u.end_mileage,
+	       u.start_engine_hours, u.end_engine_hours, u.start_pump_hours, u.end_pump_hours,
+	       u.recorded_by, u.recorded_at, a.name, c.incident_number, c.dispatched
+	FROM apparatus_usage u
+	JOIN apparatus a ON u.apparatus_id = a.id
+	JOIN calls c ON u.call_id = c.id`
+
+// GetCallApparatusUsage returns the readings recorded for each unit on a call
+func (db *DB) GetCallApparatusUsage(callID int) ([]ApparatusUsage, error) {
+	return db.queryUsage(usageSelect+`
+		WHERE u.call_id = ?
+		ORDER BY a.sort_order, a.name
+	`, callID)
+}
+
+// GetApparatusUsageHistory returns a page of a unit's readings, most
+// recent call first. Pages are by offset since the order comes from the
+// joined calls.
+func (db *DB) GetApparatusUsageHistory(apparatusID int, cursor string, limit int) (*ApparatusUsagePage, error) {
+	offset, err := offsetCursor(cursor)
+	if err != nil {
+		return nil, err
+	}
+	limit = pageSize(limit)
+
+	page := &ApparatusUsagePage{}
+	err = db.QueryRow("SELECT COUNT(*) FROM apparatus_usage WHERE apparatus_id = ?", apparatusID).Scan(&page.Total)
+	if err != nil {
+		return nil, err
+	}
+
+	page.Items, err = db.queryUsage(usageSelect+`
+		WHERE u.apparatus_id = ?
+		ORDER BY c.dispatched DESC, u.id DESC
+		LIMIT ? OFFSET ?
+	`, apparatusID, limit+1, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	var more bool
+	if page.Items, more = trimPage(page.Items, limit); more {
+		page.NextCursor = fmt.Sprintf("offset:%d", offset+limit)
+	}
+	return page, nil
+}
+
+// queryUsage runs a usage query and scans the results
+func (db *DB) queryUsage(query string, args ...interface{}) ([]ApparatusUsage, error) {
+	rows, err := db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var usage []ApparatusUsage
+	for rows.Next() {
+		var u ApparatusUsage
+		var recordedBy sql.NullInt64
+		err := rows.Scan(&u.ID, &u.CallID, &u.ApparatusID, &u.StartMileage, &u.EndMileage,
+			&u.StartEngineHours, &u.EndEngineHours, &u.StartPumpHours, &u.EndPumpHours,
+			&recordedBy, &u.RecordedAt, &u.ApparatusName, &u.IncidentNumber, &u.Dispatched)
+		if err != nil {
+			return nil, err
+		}
+		u.RecordedBy = int(recordedBy.Int64)
+		usage = append(usage, u)
+	}
+	return usage, rows.Err()
+}
+
+// RecordApparatusService records maintenance performed on a unit. The
+// readings become the baseline for the maintenance-due report.
+func (db *DB) RecordApparatusService(service *ApparatusService) error {
+	if service.ServiceDate.IsZero() {
+		return errors.New("service date is required")
+	}
+
+	var recordedBy interface{}
+	if service.RecordedBy > 0 {
+		recordedBy = service.RecordedBy
+	}
+
+	result, err := db.Exec(`
+		INSERT INTO apparatus_service (apparatus_id, service_date, mileage, engine_hours, pump_hours, notes, recorded_by)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+	`, service.ApparatusID, service.ServiceDate, service.Mileage, service.EngineHours,
+		service.PumpHours, service.Notes, recordedBy)
+	if err != nil {
+		return err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+	service.ID = int(id)
+	return nil
+}
+
+// GetMaintenanceDueReport lists the active units whose mileage, engine hours
+// or pump hours since their last service meet the configured thresholds.
+// A threshold of 0 turns that check off.
+func (db *DB) GetMaintenanceDueReport() ([]MaintenanceDue, error) {
+	milesLimit := db.GetIntSetting("maintenance_interval_miles", 5000)
+	engineLimit := db.GetIntSetting("maintenance_interval_engine_hours", 250)
+	pumpLimit := db.GetIntSetting("maintenance_interval_pump_hours", 50)
+
+	units, err := db.GetActiveApparatus()
+	if err != nil {
+		return nil, err
+	}
+
+	var report []MaintenanceDue
+	for _, unit := range units {
+		var current [3]sql.NullFloat64
+		err := db.QueryRow(`
+			SELECT MAX(COALESCE(end_mileage, start_mileage)),
+			       MAX(COALESCE(end_engine_hours, start_engine_hours)),
+			       MAX(COALESCE(end_pump_hours, start_pump_hours))
+			FROM apparatus_usage WHERE apparatus_id = ?
+		`, unit.ID).Scan(&current[0], &current[1], &current[2])
+		if err != nil {
+			return nil, err
+		}
+
+		// The baseline is the most recent service reading of each kind, or
+		// the unit's first recorded reading if it has never been serviced
+		var baseline [3]sql.NullFloat64
+		var lastService sql.NullTime
+		err = db.QueryRow(`
+			SELECT
+				COALESCE((SELECT mileage FROM apparatus_service WHERE apparatus_id = ?1 AND mileage IS NOT NULL ORDER BY service_date DESC, id DESC LIMIT 1),
+				         (SELECT MIN(COALESCE(start_mileage, end_mileage)) FROM apparatus_usage WHERE apparatus_id = ?1)),
+				COALESCE((SELECT engine_hours FROM apparatus_service WHERE apparatus_id = ?1 AND engine_hours IS NOT NULL ORDER BY service_date DESC, id DESC LIMIT 1),
+				         (SELECT MIN(COALESCE(start_engine_hours, end_engine_hours)) FROM apparatus_usage WHERE apparatus_id = ?1)),
+				COALESCE((SELECT pump_hours FROM apparatus_service WHERE apparatus_id = ?1 AND pump_hours IS NOT NULL ORDER BY service_date DESC, id DESC LIMIT 1),
+				         (SELECT MIN(COALESCE(start_pump_hours, end_pump_hours)) FROM apparatus_usage WHERE apparatus_id = ?1))
+		`, unit.ID).Scan(&baseline[0], &baseline[1], &baseline[2])
+		if err != nil {
+			return nil, err
+		}
+		err = db.QueryRow(`
+			SELECT service_date FROM apparatus_service WHERE apparatus_id = ?
+			ORDER BY service_date DESC, id DESC LIMIT 1
+		`, unit.ID).Scan(&lastService)
+		if err != nil && err != sql.ErrNoRows {
+			return nil, err
+		}
+
+		var since [3]float64
+		for i := range current {
+			if current[i].Valid && baseline[i].Valid {
+				since[i] = current[i].Float64 - baseline[i].Float64
+			}
+		}
+
+		due := MaintenanceDue{
+			Apparatus:               unit,
+			CurrentMileage:          current[0].Float64,
+			MilesSinceService:       since[0],
+			EngineHoursSinceService: since[1],
+			PumpHoursSinceService:   since[2],
+		}
+		if lastService.Valid {
+			due.LastServiceDate = &lastService.Time
+		}
+
+		if milesLimit > 0 && since[0] >= float64(milesLimit) {
+			due.Reasons = append(due.Reasons, fmt.Sprintf("%.0f miles since service (every %d)", since[0], milesLimit))
+		}
+		if engineLimit > 0 && since[1] >= float64(engineLimit) {
+			due.Reasons = append(due.Reasons, fmt.Sprintf("%.1f engine hours since service (every %d)", since[1], engineLimit))
+		}
+		if pumpLimit > 0 && since[2] >= float64(pumpLimit) {
+			due.Reasons = append(due.Reasons, fmt.Sprintf("%.1f pump hours since service (every %d)", since[2], pumpLimit))
+		}
+		if len(due.Reasons) > 0 {
+			report = append(report, due)
+		}
+	}
+	return report, nil
+}
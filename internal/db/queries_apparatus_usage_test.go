@@ -0,0 +1,186 @@
+package db
+
+import (
+	"testing"
+	"time"
+)
+
+func floatPtr(f float64) *float64 {
+	return &f
+}
+
+func createUsageCall(t *testing.T, db *DB, dispatched time.Time, apparatusID int) *Call {
+	t.Helper()
+	call := &Call{
+		CallType:   "Structure Fire",
+		Address:    "8 Depot St",
+		Town:       "Stamford",
+		Dispatched: dispatched,
+		Narrative:  "Test narrative",
+		CreatedBy:  1,
+	}
+	if err := db.CreateCall(call, []int{apparatusID}, nil, nil); err != nil {
+		t.Fatalf("Failed to create call: %v", err)
+	}
+	return call
+}
+
+func TestApparatusUsageMonotonic(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	engine := &Apparatus{Name: "Engine 7", UnitType: "Engine"}
+	if err := db.CreateApparatus(engine); err != nil {
+		t.Fatalf("Failed to create apparatus: %v", err)
+	}
+
+	base := time.Now().Add(-48 * time.Hour)
+	first := createUsageCall(t, db, base, engine.ID)
+	second := createUsageCall(t, db, base.Add(24*time.Hour), engine.ID)
+
+	err := db.SaveApparatusUsage(&ApparatusUsage{CallID: first.ID, ApparatusID: engine.ID,
+		StartMileage: floatPtr(1000), EndMileage: floatPtr(1012), StartPumpHours: floatPtr(40), EndPumpHours: floatPtr(41.5)})
+	if err != nil {
+		t.Fatalf("Failed to save usage: %v", err)
+	}
+
+	err = db.SaveApparatusUsage(&ApparatusUsage{CallID: second.ID, ApparatusID: engine.ID,
+		StartMileage: floatPtr(1005), EndMileage: floatPtr(1020)})
+	if err == nil {
+		t.Error("Expected error for mileage below the previous call's reading")
+	}
+
+	err = db.SaveApparatusUsage(&ApparatusUsage{CallID: second.ID, ApparatusID: engine.ID,
+		StartMileage: floatPtr(1030), EndMileage: floatPtr(1025)})
+	if err == nil {
+		t.Error("Expected error for ending mileage below starting mileage")
+	}
+
+	err = db.SaveApparatusUsage(&ApparatusUsage{CallID: second.ID, ApparatusID: engine.ID,
+		StartMileage: floatPtr(1012), EndMileage: floatPtr(1020)})
+	if err != nil {
+		t.Fatalf("Failed to save second usage: %v", err)
+	}
+
+	// Correcting the earlier call must not pass the later call's reading
+	err = db.SaveApparatusUsage(&ApparatusUsage{CallID: first.ID, ApparatusID: engine.ID,
+		StartMileage: floatPtr(1000), EndMileage: floatPtr(1015)})
+	if err == nil {
+		t.Error("Expected error for mileage above a later call's reading")
+	}
+
+	other := &Apparatus{Name: "Tanker 7", UnitType: "Tanker"}
+	if err := db.CreateApparatus(other); err != nil {
+		t.Fatalf("Failed to create apparatus: %v", err)
+	}
+	if err := db.SaveApparatusUsage(&ApparatusUsage{CallID: first.ID, ApparatusID: other.ID}); err == nil {
+		t.Error("Expected error for a unit not assigned to the call")
+	}
+
+	history, err := db.GetApparatusUsageHistory(engine.ID, "", 1)
+	if err != nil {
+		t.Fatalf("Failed to get history: %v", err)
+	}
+	if history.Total != 2 || len(history.Items) != 1 || history.Items[0].CallID != second.ID || history.Items[0].ApparatusName != "Engine 7" {
+		t.Errorf("Unexpected usage history: %+v", history)
+	}
+	history, err = db.GetApparatusUsageHistory(engine.ID, history.NextCursor, 1)
+	if err != nil {
+		t.Fatalf("Failed to get second page of history: %v", err)
+	}
+	if len(history.Items) != 1 || history.Items[0].CallID != first.ID || history.NextCursor != "" {
+		t.Errorf("Expected the first call on the last page, got %+v", history)
+	}
+}
+
+func TestMaintenanceDueReport(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	if err := db.UpdateSetting("maintenance_interval_miles", "100"); err != nil {
+		t.Fatalf("Failed to update setting: %v", err)
+	}
+
+	engine := &Apparatus{Name: "Engine 8", UnitType: "Engine"}
+	if err := db.CreateApparatus(engine); err != nil {
+		t.Fatalf("Failed to create apparatus: %v", err)
+	}
+	call := createUsageCall(t, db, time.Now(), engine.ID)
+	err := db.SaveApparatusUsage(&ApparatusUsage{CallID: call.ID, ApparatusID: engine.ID,
+		StartMileage: floatPtr(500), EndMileage: floatPtr(650)})
+	if err != nil {
+		t.Fatalf("Failed to save usage: %v", err)
+	}
+
+	report, err := db.GetMaintenanceDueReport()
+	if err != nil {
+		t.Fatalf("Failed to get report: %v", err)
+	}
+	if len(report) != 1 || report[0].Apparatus.ID != engine.ID || report[0].MilesSinceService != 150 {
+		t.Fatalf("Expected Engine 8 due with 150 miles, got %+v", report)
+	}
+
+	service := &ApparatusService{ApparatusID: engine.ID, ServiceDate: time.Now(), Mileage: floatPtr(650), Notes: "Oil change"}
+	if err := db.RecordApparatusService(service); err != nil {
+		t.Fatalf("Failed to record service: %v", err)
+	}
+	report, err = db.GetMaintenanceDueReport()
+	if err != nil {
+		t.Fatalf("Failed to get report: %v", err)
+	}
+	if len(report) != 0 {
+		t.Errorf("Expected no units due after service, got %d", len(report))
+	}
+}
+
+func TestApparatusUsageSameDispatchAndRemoval(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	engine := &Apparatus{Name: "Engine 7", UnitType: "Engine"}
+	if err := db.CreateApparatus(engine); err != nil {
+		t.Fatalf("Failed to create apparatus: %v", err)
+	}
+
+	// Two calls dispatched together are ordered by ID
+	dispatched := time.Now().Add(-time.Hour).Truncate(time.Second)
+	first := createUsageCall(t, db, dispatched, engine.ID)
+	second := createUsageCall(t, db, dispatched, engine.ID)
+
+	err := db.SaveApparatusUsage(&ApparatusUsage{CallID: first.ID, ApparatusID: engine.ID,
+		StartMileage: floatPtr(1000), EndMileage: floatPtr(1010)})
+	if err != nil {
+		t.Fatalf("Failed to save usage: %v", err)
+	}
+	err = db.SaveApparatusUsage(&ApparatusUsage{CallID: second.ID, ApparatusID: engine.ID,
+		StartMileage: floatPtr(990), EndMileage: floatPtr(995)})
+	if err == nil {
+		t.Error("Expected error for mileage below a call dispatched at the same time")
+	}
+	err = db.SaveApparatusUsage(&ApparatusUsage{CallID: second.ID, ApparatusID: engine.ID,
+		StartMileage: floatPtr(1010), EndMileage: floatPtr(1015)})
+	if err != nil {
+		t.Fatalf("Failed to save usage: %v", err)
+	}
+
+	// Taking the unit off a call removes its readings
+	call, _, _, err := db.GetCallByID(second.ID)
+	if err != nil {
+		t.Fatalf("Failed to get call: %v", err)
+	}
+	if err := db.UpdateCall(call, nil, nil, nil); err != nil {
+		t.Fatalf("Failed to update call: %v", err)
+	}
+	usage, err := db.GetCallApparatusUsage(second.ID)
+	if err != nil || len(usage) != 0 {
+		t.Errorf("Expected readings removed with the unit, got %d (%v)", len(usage), err)
+	}
+
+	if _, err := db.BulkRemoveApparatus(CallFilter{Text: "Depot"}, engine.ID, 1, false); err != nil {
+		t.Fatalf("Failed to bulk remove apparatus: %v", err)
+	}
+	usage, err = db.GetCallApparatusUsage(first.ID)
+	if err != nil || len(usage) != 0 {
+		t.Errorf("Expected readings removed by bulk remove, got %d (%v)", len(usage), err)
+	}
+}
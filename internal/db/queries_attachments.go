@@ -0,0 +1,164 @@
+package db
+
+import (
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// defaultAttachmentMaxBytes is used when attachment_max_bytes is not set (10 MB)
+const defaultAttachmentMaxBytes = 10 * 1024 * 1024
+
+// GetAttachmentMaxBytes returns the configured attachment size cap
+func (db *DB) GetAttachmentMaxBytes() int {
+	return db.GetIntSetting("attachment_max_bytes", defaultAttachmentMaxBytes)
+}
+
+// CreateAttachment stores a file on a call. The MIME type is detected from
+// the content when not given, and the SHA-256 hash and size are computed here.
+func (db *DB) CreateAttachment(callID int, fileName, mimeType, caption string, data []byte, uploadedBy int) (*Attachment, error) {
+	if len(data) == 0 {
+		return nil, fmt.Errorf("attachment is empty")
+	}
+	if maxBytes := db.GetAttachmentMaxBytes(); len(data) > maxBytes {
+		return nil, fmt.Errorf("attachment is %d bytes, larger than the %d byte limit", len(data), maxBytes)
+	}
+	if mimeType == "" {
+		mimeType = http.DetectContentType(data)
+	}
+
+	sum := sha256.Sum256(data)
+	attachment := &Attachment{
+		CallID:   callID,
+		FileName: fileName,
+		MimeType: mimeType,
+		Size:     len(data),
+		SHA256:   hex.EncodeToString(sum[:]),
+		Caption:  caption,
+	}
+
+	// Verify user exists, otherwise use NULL for uploaded_by
+	var userExists bool
+	err := db.QueryRow("SELECT EXISTS(SELECT 1 FROM users WHERE id = ?)", uploadedBy).Scan(&userExists)
+	if err != nil {
+		return nil, fmt.Errorf("failed to verify user: %w", err)
+	}
+	var uploader interface{}
+	if userExists {
+		uploader = uploadedBy
+		attachment.UploadedBy = uploadedBy
+	}
+
+	result, err := db.Exec(`
+		INSERT INTO call_attachments (call_id, file_name, mime_type, size_bytes, sha256, caption, data, uploaded_by)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+	`, callID, attachment.FileName, attachment.MimeType, attachment.Size, attachment.SHA256, attachment.Caption, data, uploader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save attachment: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return nil, err
+	}
+	attachment.ID = int(id)
+	return attachment, nil
+}
+
+// GetAttachmentsByCallID lists a call's attachments without their file data.
+// Soft-deleted attachments are left out.
+func (db *DB) GetAttachmentsByCallID(callID int) ([]Attachment, error) {
+	rows, err := db.Query(`
+		SELECT id, call_id, file_name, mime_type, size_bytes, sha256, caption,
+		       COALESCE(uploaded_by, 0), uploaded_at
+		FROM call_attachments
+		WHERE call_id = ? AND deleted_at IS NULL
+		ORDER BY uploaded_at, id
+	`, callID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var attachments []Attachment
+	for rows.Next() {
+		var a Attachment
+		err := rows.Scan(&a.ID, &a.CallID, &a.FileName, &a.MimeType, &a.Size, &a.SHA256, &a.Caption,
+			&a.UploadedBy, &a.UploadedAt)
+		if err != nil {
+			return nil, err
+		}
+		attachments = append(attachments, a)
+	}
+	return attachments, nil
+}
+
+// GetAttachment returns an attachment with its file data, or nil if it does
+// not exist or was deleted
+func (db *DB) GetAttachment(id int) (*Attachment, error) {
+	var a Attachment
+	err := db.QueryRow(`
+		SELECT id, call_id, file_name, mime_type, size_bytes, sha256, caption,
+		       COALESCE(uploaded_by, 0), uploaded_at, data
+		FROM call_attachments
+		WHERE id = ? AND deleted_at IS NULL
+	`, id).Scan(&a.ID, &a.CallID, &a.FileName, &a.MimeType, &a.Size, &a.SHA256, &a.Caption,
+		&a.UploadedBy, &a.UploadedAt, &a.Data)
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get attachment: %w", err)
+	}
+	return &a, nil
+}
+
+// GetImageAttachments returns a call's image attachments with their data,
+// for embedding in reports
+func (db *DB) GetImageAttachments(callID int) ([]Attachment, error) {
+	attachments, err := db.GetAttachmentsByCallID(callID)
+	if err != nil {
+		return nil, err
+	}
+
+	var images []Attachment
+	for _, a := range attachments {
+		if !strings.HasPrefix(a.MimeType, "image/") {
+			continue
+		}
+		full, err := db.GetAttachment(a.ID)
+		if err != nil {
+			return nil, err
+		}
+		if full != nil {
+			images = append(images, *full)
+		}
+	}
+	return images, nil
+}
+
+// DeleteAttachment soft-deletes an attachment. The file data is kept so the
+// record can be restored if it was removed by mistake.
+func (db *DB) DeleteAttachment(id, deletedBy int) error {
+	result, err := db.Exec(`
+		UPDATE call_attachments
+		SET deleted_at = CURRENT_TIMESTAMP, deleted_by = ?
+		WHERE id = ? AND deleted_at IS NULL
+	`, deletedBy, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete attachment: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to check affected rows: %w", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("no attachment found to delete")
+	}
+	return nil
+}
@@ -0,0 +1,59 @@
+package db
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCreateAndDownloadAttachment(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	call := createTestCall(t, db, "Structure Fire", "3 Mill Rd", time.Now())
+
+	data := []byte{0x89, 0x50, 0x4E, 0x47, 0x0D, 0x0A, 0x1A, 0x0A}
+	attachment, err := db.CreateAttachment(call.ID, "scene.png", "", "Front of house", data, 1)
+	if err != nil {
+		t.Fatalf("Failed to create attachment: %v", err)
+	}
+	if attachment.MimeType != "image/png" {
+		t.Errorf("Expected detected mime type image/png, got %s", attachment.MimeType)
+	}
+	if attachment.SHA256 != "4c4b6a3be1314ab86138bef4314dde022e600960d8689a2c8f8631802d20dab6" {
+		t.Errorf("Unexpected SHA-256: %s", attachment.SHA256)
+	}
+
+	downloaded, err := db.GetAttachment(attachment.ID)
+	if err != nil {
+		t.Fatalf("Failed to get attachment: %v", err)
+	}
+	if downloaded == nil || len(downloaded.Data) != len(data) {
+		t.Fatalf("Expected %d bytes of data, got %+v", len(data), downloaded)
+	}
+
+	if err := db.DeleteAttachment(attachment.ID, 1); err != nil {
+		t.Fatalf("Failed to delete attachment: %v", err)
+	}
+	list, err := db.GetAttachmentsByCallID(call.ID)
+	if err != nil {
+		t.Fatalf("Failed to list attachments: %v", err)
+	}
+	if len(list) != 0 {
+		t.Errorf("Expected deleted attachment to be hidden, got %d", len(list))
+	}
+}
+
+func TestAttachmentSizeLimit(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	call := createTestCall(t, db, "Structure Fire", "3 Mill Rd", time.Now())
+	if err := db.UpdateSetting("attachment_max_bytes", "4"); err != nil {
+		t.Fatalf("Failed to update setting: %v", err)
+	}
+
+	_, err := db.CreateAttachment(call.ID, "big.bin", "application/octet-stream", "", []byte("12345"), 1)
+	if err == nil {
+		t.Error("Expected error for attachment over the size limit")
+	}
+}
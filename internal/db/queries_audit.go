@@ -0,0 +1,83 @@
+package db
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+)
+
+// insertAuditLog records an action in the audit log as part of tx. Changes
+// are stored as JSON. recordID may be 0 for actions spanning many records.
+func insertAuditLog(tx *sql.Tx, userID int, action, tableName string, recordID int, changes interface{}) error {
+	data, err := json.Marshal(changes)
+	if err != nil {
+		return fmt.Errorf("failed to encode audit changes: %w", err)
+	}
+
+	var record interface{}
+	if recordID != 0 {
+		record = recordID
+	}
+
+	_, err = tx.Exec(`
+		INSERT INTO audit_log (user_id, action, table_name, record_id, changes)
+		VALUES (?, ?, ?, ?, ?)
+	`, userID, action, tableName, record, string(data))
+	if err != nil {
+		return fmt.Errorf("failed to write audit log: %w", err)
+	}
+	return nil
+}
+
+// GetAuditLog returns a page of audit entries, most recent first. Pass ""
+// as cursor for the first page.
+func (db *DB) GetAuditLog(cursor string, limit int) (*AuditLogPage, error) {
+	afterID, err := keysetCursor(cursor)
+	if err != nil {
+		return nil, err
+	}
+	limit = pageSize(limit)
+
+	page := &AuditLogPage{}
+	if err := db.QueryRow("SELECT COUNT(*) FROM audit_log").Scan(&page.Total); err != nil {
+		return nil, err
+	}
+
+	var where string
+	var args []interface{}
+	if afterID != 0 {
+		if where, err = db.afterRow("audit_log", "timestamp", "<", afterID); err != nil {
+			return nil, err
+		}
+		args = append(args, afterID)
+	}
+
+	rows, err := db.Query(`
+		SELECT id, user_id, action, table_name, COALESCE(record_id, 0), COALESCE(changes, ''), timestamp
+		FROM audit_log
+		WHERE 1=1`+where+`
+		ORDER BY timestamp DESC, id DESC
+		LIMIT ?
+	`, append(args, limit+1)...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var e AuditLog
+		if err := rows.Scan(&e.ID, &e.UserID, &e.Action, &e.TableName, &e.RecordID, &e.Changes, &e.Timestamp); err != nil {
+			return nil, err
+		}
+		page.Items = append(page.Items, e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	var more bool
+	if page.Items, more = trimPage(page.Items, limit); more {
+		page.NextCursor = fmt.Sprintf("after:%d", page.Items[limit-1].ID)
+	}
+	return page, nil
+}
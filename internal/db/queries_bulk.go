@@ -0,0 +1,225 @@
+package db
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+)
+
+// bulkSettableFields are the call columns that can be changed in bulk, with
+// the picklist category each value must come from ("" for free text)
+var bulkSettableFields = map[string]string{
+	"call_type":          "call_type",
+	"town":               "town",
+	"mutual_aid":         "",
+	"location_notes":     "",
+	"incident_type_code": "",
+}
+
+// bulkChange describes one bulk operation for runBulk
+type bulkChange struct {
+	action  string
+	details map[string]interface{}
+	// affected narrows the filtered calls to those the change would alter
+	affected     string
+	affectedArgs []interface{}
+	apply        func(tx *sql.Tx, callIDs []int) error
+}
+
+// BulkSetCallField sets one field to value on every call matching filter
+func (db *DB) BulkSetCallField(filter CallFilter, field, value string, userID int, dryRun bool) (*BulkResult, error) {
+	category, ok := bulkSettableFields[field]
+	if !ok {
+		return nil, fmt.Errorf("field %q cannot be changed in bulk", field)
+	}
+	if category != "" {
+		if value == "" {
+			return nil, fmt.Errorf("%s cannot be blank", field)
+		}
+		if err := db.validatePicklistValue(category, value); err != nil {
+			return nil, err
+		}
+	}
+	if field == "incident_type_code" {
+		if err := db.ValidateIncidentTypeCode(value); err != nil {
+			return nil, err
+		}
+	}
+
+	// field is from the whitelist above, so it is safe to build into SQL
+	return db.runBulk(filter, userID, dryRun, bulkChange{
+		action:       "bulk_set_field",
+		details:      map[string]interface{}{"field": field, "value": value},
+		affected:     fmt.Sprintf("COALESCE(%s, '') != ?", field),
+		affectedArgs: []interface{}{value},
+		apply: func(tx *sql.Tx, callIDs []int) error {
+			return execForCalls(tx, fmt.Sprintf("UPDATE calls SET %s = ?, updated_at = CURRENT_TIMESTAMP WHERE id = ?", field), callIDs, value)
+		},
+	})
+}
+
+// BulkAddApparatus adds an apparatus to every call matching filter
+func (db *DB) BulkAddApparatus(filter CallFilter, apparatusID, userID int, dryRun bool) (*BulkResult, error) {
+	return db.runBulk(filter, userID, dryRun, bulkChange{
+		action:       "bulk_add_apparatus",
+		details:      map[string]interface{}{"apparatus_id": apparatusID},
+		affected:     "id NOT IN (SELECT call_id FROM call_apparatus WHERE apparatus_id = ?)",
+		affectedArgs: []interface{}{apparatusID},
+		apply: func(tx *sql.Tx, callIDs []int) error {
+			return execForCalls(tx, "INSERT INTO call_apparatus (apparatus_id, call_id) VALUES (?, ?)", callIDs, apparatusID)
+		},
+	})
+}
+
+// BulkRemoveApparatus removes an apparatus from every call matching filter
+func (db *DB) BulkRemoveApparatus(filter CallFilter, apparatusID, userID int, dryRun bool) (*BulkResult, error) {
+	return db.runBulk(filter, userID, dryRun, bulkChange{
+		action:       "bulk_remove_apparatus",
+		details:      map[string]interface{}{"apparatus_id": apparatusID},
+		affected:     "id IN (SELECT call_id FROM call_apparatus WHERE apparatus_id = ?)",
+		affectedArgs: []interface{}{apparatusID},
+		apply: func(tx *sql.Tx, callIDs []int) error {
+			if err := execForCalls(tx, "DELETE FROM apparatus_usage WHERE apparatus_id = ? AND call_id = ?", callIDs, apparatusID); err != nil {
+				return err
+			}
+			return execForCalls(tx, "DELETE FROM call_apparatus WHERE apparatus_id = ? AND call_id = ?", callIDs, apparatusID)
+		},
+	})
+}
+
+// BulkAddResponder adds a responder with an optional role to every call matching filter
+func (db *DB) BulkAddResponder(filter CallFilter, responderID int, role string, userID int, dryRun bool) (*BulkResult, error) {
+	return db.runBulk(filter, userID, dryRun, bulkChange{
+		action:       "bulk_add_responder",
+		details:      map[string]interface{}{"responder_id": responderID, "role": role},
+		affected:     "id NOT IN (SELECT call_id FROM call_responders WHERE responder_id = ?)",
+		affectedArgs: []interface{}{responderID},
+		apply: func(tx *sql.Tx, callIDs []int) error {
+			return execForCalls(tx, "INSERT INTO call_responders (responder_id, responder_role, call_id) VALUES (?, ?, ?)", callIDs, responderID, role)
+		},
+	})
+}
+
+// BulkRemoveResponder removes a responder from every call matching filter
+func (db *DB) BulkRemoveResponder(filter CallFilter, responderID, userID int, dryRun bool) (*BulkResult, error) {
+	return db.runBulk(filter, userID, dryRun, bulkChange{
+		action:       "bulk_remove_responder",
+		details:      map[string]interface{}{"responder_id": responderID},
+		affected:     "id IN (SELECT call_id FROM call_responders WHERE responder_id = ?)",
+		affectedArgs: []interface{}{responderID},
+		apply: func(tx *sql.Tx, callIDs []int) error {
+			return execForCalls(tx, "DELETE FROM call_responders WHERE responder_id = ? AND call_id = ?", callIDs, responderID)
+		},
+	})
+}
+
+// BulkReassignCreatedBy changes created_by on every call matching filter
+func (db *DB) BulkReassignCreatedBy(filter CallFilter, newUserID, userID int, dryRun bool) (*BulkResult, error) {
+	user, err := db.GetUserByID(newUserID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, fmt.Errorf("user %d not found", newUserID)
+	}
+
+	return db.runBulk(filter, userID, dryRun, bulkChange{
+		action:       "bulk_reassign_created_by",
+		details:      map[string]interface{}{"created_by": newUserID},
+		affected:     "created_by != ?",
+		affectedArgs: []interface{}{newUserID},
+		apply: func(tx *sql.Tx, callIDs []int) error {
+			return execForCalls(tx, "UPDATE calls SET created_by = ?, updated_at = CURRENT_TIMESTAMP WHERE id = ?", callIDs, newUserID)
+		},
+	})
+}
+
+// runBulk selects the calls a change would alter and, unless dryRun is set,
+// applies it and writes one grouped audit entry in a single transaction
+func (db *DB) runBulk(filter CallFilter, userID int, dryRun bool, change bulkChange) (*BulkResult, error) {
+	where, args, err := filter.where()
+	if err != nil {
+		return nil, err
+	}
+	if where == "" {
+		return nil, errors.New("bulk operations need at least one filter")
+	}
+
+	var userExists bool
+	err = db.QueryRow("SELECT EXISTS(SELECT 1 FROM users WHERE id = ?)", userID).Scan(&userExists)
+	if err != nil {
+		return nil, fmt.Errorf("failed to verify user: %w", err)
+	}
+	if !userExists {
+		return nil, errors.New("bulk changes must be made by a named user so they can be audited")
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	args = append(args, change.affectedArgs...)
+	rows, err := tx.Query(`
+		SELECT `+callColumns+`
+		FROM calls
+		WHERE 1=1`+where+` AND `+change.affected+`
+		ORDER BY dispatched, id
+	`, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &BulkResult{DryRun: dryRun}
+	var callIDs []int
+	for rows.Next() {
+		var call Call
+		if err := scanCall(rows, &call); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		result.Calls = append(result.Calls, call)
+		callIDs = append(callIDs, call.ID)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	result.Affected = len(callIDs)
+
+	if dryRun || len(callIDs) == 0 {
+		return result, nil
+	}
+
+	if err := change.apply(tx, callIDs); err != nil {
+		return nil, err
+	}
+
+	change.details["filter"] = filter
+	change.details["call_ids"] = callIDs
+	if err := insertAuditLog(tx, userID, change.action, "calls", 0, change.details); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// execForCalls runs stmt once per call, with the call ID as the last argument
+func execForCalls(tx *sql.Tx, stmt string, callIDs []int, args ...interface{}) error {
+	prepared, err := tx.Prepare(stmt)
+	if err != nil {
+		return err
+	}
+	defer prepared.Close()
+
+	for _, id := range callIDs {
+		if _, err := prepared.Exec(append(args, id)...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+package db
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBulkSetCallFieldDryRunAndApply(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	base := time.Date(2026, 2, 1, 12, 0, 0, 0, time.UTC)
+	for i := 0; i < 3; i++ {
+		call := createTestCall(t, db, "Rescue", "1 Bridge St", base.Add(time.Duration(i)*time.Hour))
+		if _, err := db.Exec("UPDATE calls SET town = 'Stamfrod' WHERE id = ?", call.ID); err != nil {
+			t.Fatalf("Failed to set misspelled town: %v", err)
+		}
+	}
+	createTestCall(t, db, "Rescue", "1 Bridge St", base)
+
+	filter := CallFilter{Towns: []string{"Stamfrod"}}
+	preview, err := db.BulkSetCallField(filter, "town", "Stamford", 1, true)
+	if err != nil {
+		t.Fatalf("Dry run failed: %v", err)
+	}
+	if preview.Affected != 3 {
+		t.Fatalf("Expected 3 calls in preview, got %d", preview.Affected)
+	}
+
+	var remaining int
+	db.QueryRow("SELECT COUNT(*) FROM calls WHERE town = 'Stamfrod'").Scan(&remaining)
+	if remaining != 3 {
+		t.Fatalf("Dry run changed data: %d misspelled calls left", remaining)
+	}
+
+	result, err := db.BulkSetCallField(filter, "town", "Stamford", 1, false)
+	if err != nil {
+		t.Fatalf("Bulk update failed: %v", err)
+	}
+	if result.Affected != 3 {
+		t.Errorf("Expected 3 calls updated, got %d", result.Affected)
+	}
+
+	db.QueryRow("SELECT COUNT(*) FROM calls WHERE town = 'Stamfrod'").Scan(&remaining)
+	if remaining != 0 {
+		t.Errorf("Expected no misspelled calls left, got %d", remaining)
+	}
+
+	entries, err := db.GetAuditLog("", 10)
+	if err != nil {
+		t.Fatalf("Failed to read audit log: %v", err)
+	}
+	if entries.Total != 1 || entries.Items[0].Action != "bulk_set_field" {
+		t.Errorf("Expected one grouped audit entry, got %+v", entries)
+	}
+}
+
+func TestBulkRequiresFilter(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	if _, err := db.BulkReassignCreatedBy(CallFilter{Sort: CallSortTown}, 1, 1, true); err == nil {
+		t.Error("Expected error for bulk operation without filters")
+	}
+}
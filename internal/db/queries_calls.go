@@ -7,6 +7,29 @@ import (
 	"time"
 )
 
+// callColumns is the column list read by scanCall
+const callColumns = `id, incident_number, call_type, mutual_aid,
+		       address, town, location_notes,
+		       dispatched, enroute, on_scene, clear,
+		       narrative, incident_type_code, address_key,
+		       weather_temp_f, weather_wind, weather_precipitation, road_conditions,
+		       created_by, created_at, updated_at`
+
+// rowScanner is satisfied by both *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// scanCall scans a row selected with callColumns into call
+func scanCall(row rowScanner, call *Call) error {
+	return row.Scan(&call.ID, &call.IncidentNumber, &call.CallType, &call.MutualAid,
+		&call.Address, &call.Town, &call.LocationNotes,
+		&call.Dispatched, &call.Enroute, &call.OnScene, &call.Clear,
+		&call.Narrative, &call.IncidentTypeCode, &call.AddressKey,
+		&call.TemperatureF, &call.Wind, &call.Precipitation, &call.RoadConditions,
+		&call.CreatedBy, &call.CreatedAt, &call.UpdatedAt)
+}
+
 // CreateCall creates a new call with apparatus and responders
 func (db *DB) CreateCall(call *Call, apparatusIDs []int, responderIDs []int, responderRoles []string) error {
 	tx, err := db.Begin()
@@ -15,6 +38,11 @@ func (db *DB) CreateCall(call *Call, apparatusIDs []int, responderIDs []int, res
 	}
 	defer tx.Rollback()
 
+	if err := db.ValidateIncidentTypeCode(call.IncidentTypeCode); err != nil {
+		return err
+	}
+	call.AddressKey = NormalizeAddress(call.Address)
+
 	// Auto-generate incident number if not provided
 	if call.IncidentNumber == "" {
 		year := call.Dispatched.Year()
@@ -24,17 +52,23 @@ func (db *DB) CreateCall(call *Call, apparatusIDs []int, responderIDs []int, res
 		}
 	}
 
+	if err := db.ValidateCallFields(call, apparatusIDs, responderIDs); err != nil {
+		return err
+	}
+
 	// Insert call
 	result, err := tx.Exec(`
 		INSERT INTO calls (
 			incident_number, call_type, mutual_aid, address, 
 			town, location_notes, dispatched, enroute, 
-			on_scene, clear, narrative, created_by
-		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+			on_scene, clear, narrative, incident_type_code, address_key,
+			weather_temp_f, weather_wind, weather_precipitation, road_conditions, created_by
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 	`, call.IncidentNumber, call.CallType, call.MutualAid,
 		call.Address, call.Town, call.LocationNotes,
 		call.Dispatched, call.Enroute, call.OnScene, call.Clear,
-		call.Narrative, call.CreatedBy)
+		call.Narrative, call.IncidentTypeCode, call.AddressKey,
+		call.TemperatureF, call.Wind, call.Precipitation, call.RoadConditions, call.CreatedBy)
 	
 	if err != nil {
 		return err
@@ -72,49 +106,36 @@ func (db *DB) CreateCall(call *Call, apparatusIDs []int, responderIDs []int, res
 		}
 	}
 
+	if err := saveCustomValues(tx, call.ID, call.CustomFields); err != nil {
+		return err
+	}
+
 	return tx.Commit()
 }
 
 // GetCallByID returns a call by ID with apparatus and responders
-func (db *DB) GetCallByID(id int) (*Call, []Picklist, []User, error) {
+func (db *DB) GetCallByID(id int) (*Call, []Apparatus, []User, error) {
 	var call Call
-	err := db.QueryRow(`
-		SELECT c.id, c.incident_number, c.call_type, c.mutual_aid,
-		       c.address, c.town, c.location_notes,
-		       c.dispatched, c.enroute, c.on_scene, c.clear,
-		       c.narrative, c.created_by, c.created_at, c.updated_at
-		FROM calls c WHERE c.id = ?
-	`, id).Scan(&call.ID, &call.IncidentNumber, &call.CallType, &call.MutualAid,
-		&call.Address, &call.Town, &call.LocationNotes,
-		&call.Dispatched, &call.Enroute, &call.OnScene, &call.Clear,
-		&call.Narrative, &call.CreatedBy, &call.CreatedAt, &call.UpdatedAt)
+	err := scanCall(db.QueryRow(`
+		SELECT `+callColumns+`
+		FROM calls WHERE id = ?
+	`, id), &call)
 
 	if err != nil {
 		return nil, nil, nil, err
 	}
 
 	// Get apparatus
-	apparatusRows, err := db.Query(`
-		SELECT p.id, p.category, p.value, p.sort_order, p.active
+	apparatus, err := db.queryApparatus(`
+		SELECT a.id, a.name, a.unit_type, a.seats, a.pump_gpm, a.station, a.in_service, a.active, a.sort_order, a.created_at
 		FROM call_apparatus ca
-		JOIN picklists p ON ca.apparatus_id = p.id
+		JOIN apparatus a ON ca.apparatus_id = a.id
 		WHERE ca.call_id = ?
-		ORDER BY p.sort_order, p.value
+		ORDER BY a.sort_order, a.name
 	`, id)
 	if err != nil {
 		return nil, nil, nil, err
 	}
-	defer apparatusRows.Close()
-
-	var apparatus []Picklist
-	for apparatusRows.Next() {
-		var app Picklist
-		err := apparatusRows.Scan(&app.ID, &app.Category, &app.Value, &app.SortOrder, &app.Active)
-		if err != nil {
-			return nil, nil, nil, err
-		}
-		apparatus = append(apparatus, app)
-	}
 
 	// Get responders
 	responderRows, err := db.Query(`
@@ -141,73 +162,31 @@ func (db *DB) GetCallByID(id int) (*Call, []Picklist, []User, error) {
 		responders = append(responders, user)
 	}
 
-	return &call, apparatus, responders, nil
-}
-
-// GetRecentCalls returns recent calls with pagination
-func (db *DB) GetRecentCalls(limit, offset int) ([]Call, error) {
-	rows, err := db.Query(`
-		SELECT id, incident_number, call_type, mutual_aid,
-		       address, town, location_notes,
-		       dispatched, enroute, on_scene, clear,
-		       narrative, created_by, created_at, updated_at
-		FROM calls
-		ORDER BY created_at DESC
-		LIMIT ? OFFSET ?
-	`, limit, offset)
+	call.Links, err = db.GetCallLinks(id)
 	if err != nil {
-		return nil, err
+		return nil, nil, nil, err
 	}
-	defer rows.Close()
 
-	var calls []Call
-	for rows.Next() {
-		var call Call
-		err := rows.Scan(&call.ID, &call.IncidentNumber, &call.CallType, &call.MutualAid,
-			&call.Address, &call.Town, &call.LocationNotes,
-			&call.Dispatched, &call.Enroute, &call.OnScene, &call.Clear,
-			&call.Narrative, &call.CreatedBy, &call.CreatedAt, &call.UpdatedAt)
-		if err != nil {
-			return nil, err
-		}
-		calls = append(calls, call)
+	customValues, err := db.GetCustomValuesForCalls([]int{id})
+	if err != nil {
+		return nil, nil, nil, err
 	}
-	return calls, nil
+	call.CustomFields = customValues[id]
+
+	return &call, apparatus, responders, nil
 }
 
-// GetCallsByYear returns all calls for a specific year
-func (db *DB) GetCallsByYear(year int) ([]Call, error) {
-	// Create start and end dates for the year
-	startDate := fmt.Sprintf("%d-01-01T00:00:00Z", year)
-	endDate := fmt.Sprintf("%d-12-31T23:59:59Z", year)
-	
-	rows, err := db.Query(`
-		SELECT id, incident_number, call_type, mutual_aid,
-		       address, town, location_notes,
-		       dispatched, enroute, on_scene, clear,
-		       narrative, created_by, created_at, updated_at
-		FROM calls
-		WHERE dispatched >= ? AND dispatched <= ?
-		ORDER BY dispatched DESC
-	`, startDate, endDate)
-	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
+// GetRecentCalls returns a page of calls, most recently dispatched first
+func (db *DB) GetRecentCalls(cursor string, limit int) (*CallPage, error) {
+	return db.SearchCallsPage(CallFilter{}, cursor, limit)
+}
 
-	var calls []Call
-	for rows.Next() {
-		var call Call
-		err := rows.Scan(&call.ID, &call.IncidentNumber, &call.CallType, &call.MutualAid,
-			&call.Address, &call.Town, &call.LocationNotes,
-			&call.Dispatched, &call.Enroute, &call.OnScene, &call.Clear,
-			&call.Narrative, &call.CreatedBy, &call.CreatedAt, &call.UpdatedAt)
-		if err != nil {
-			return nil, err
-		}
-		calls = append(calls, call)
-	}
-	return calls, nil
+// GetCallsByYear returns a page of the calls dispatched in a year, newest
+// first. Pass "" as cursor for the first page.
+func (db *DB) GetCallsByYear(year int, cursor string, limit int) (*CallPage, error) {
+	start := time.Date(year, 1, 1, 0, 0, 0, 0, time.Local)
+	end := start.AddDate(1, 0, 0).Add(-time.Nanosecond)
+	return db.SearchCallsPage(CallFilter{DispatchedFrom: &start, DispatchedTo: &end}, cursor, limit)
 }
 
 // GetCallYears returns all years that have calls
@@ -237,53 +216,22 @@ func (db *DB) GetCallYears() ([]int, error) {
 	return years, nil
 }
 
-// SearchCalls searches calls based on filters
-func (db *DB) SearchCalls(filters map[string]interface{}, limit, offset int) ([]Call, error) {
-	query := `
-		SELECT id, incident_number, call_type, mutual_aid,
-		       address, town, location_notes,
-		       dispatched, enroute, on_scene, clear,
-		       narrative, created_by, created_at, updated_at
-		FROM calls
-		WHERE 1=1
-	`
-	
-	var args []interface{}
-	argIndex := 1
-
-	// Build dynamic WHERE clause
-	if startDate, ok := filters["start_date"]; ok {
-		query += " AND created_at >= ?"
-		args = append(args, startDate)
-		argIndex++
-	}
-	
-	if endDate, ok := filters["end_date"]; ok {
-		query += " AND created_at <= ?"
-		args = append(args, endDate)
-		argIndex++
-	}
-
-	if callType, ok := filters["call_type"]; ok && callType != "" {
-		query += " AND call_type = ?"
-		args = append(args, callType)
-		argIndex++
-	}
-
-	if town, ok := filters["town"]; ok && town != "" {
-		query += " AND town = ?"
-		args = append(args, town)
-		argIndex++
+// SearchCalls returns the calls matching filter in the filter's sort order
+func (db *DB) SearchCalls(filter CallFilter, limit, offset int) ([]Call, error) {
+	where, args, err := filter.where()
+	if err != nil {
+		return nil, err
 	}
-
-	if searchText, ok := filters["search_text"]; ok && searchText != "" {
-		query += " AND (address LIKE ? OR incident_number LIKE ?)"
-		searchPattern := "%" + searchText.(string) + "%"
-		args = append(args, searchPattern, searchPattern)
-		argIndex += 2
+	orderBy, err := filter.orderBy()
+	if err != nil {
+		return nil, err
 	}
 
-	query += " ORDER BY created_at DESC LIMIT ? OFFSET ?"
+	query := `
+		SELECT ` + callColumns + `
+		FROM calls
+		WHERE 1=1
+	` + where + " ORDER BY " + orderBy + " LIMIT ? OFFSET ?"
 	args = append(args, limit, offset)
 
 	rows, err := db.Query(query, args...)
@@ -295,16 +243,12 @@ func (db *DB) SearchCalls(filters map[string]interface{}, limit, offset int) ([]
 	var calls []Call
 	for rows.Next() {
 		var call Call
-		err := rows.Scan(&call.ID, &call.IncidentNumber, &call.CallType, &call.MutualAid,
-			&call.Address, &call.Town, &call.LocationNotes,
-			&call.Dispatched, &call.Enroute, &call.OnScene, &call.Clear,
-			&call.Narrative, &call.CreatedBy, &call.CreatedAt, &call.UpdatedAt)
-		if err != nil {
+		if err := scanCall(rows, &call); err != nil {
 			return nil, err
 		}
 		calls = append(calls, call)
 	}
-	return calls, nil
+	return calls, rows.Err()
 }
 
 // UpdateCall updates a call (admin only or within time limit)
@@ -315,18 +259,29 @@ func (db *DB) UpdateCall(call *Call, apparatusIDs []int, responderIDs []int, res
 	}
 	defer tx.Rollback()
 
+	if err := db.ValidateIncidentTypeCode(call.IncidentTypeCode); err != nil {
+		return err
+	}
+	if err := db.ValidateCallFields(call, apparatusIDs, responderIDs); err != nil {
+		return err
+	}
+	call.AddressKey = NormalizeAddress(call.Address)
+
 	// Update call
 	_, err = tx.Exec(`
 		UPDATE calls SET
 			incident_number = ?, call_type = ?, mutual_aid = ?,
 			address = ?, town = ?, location_notes = ?,
 			dispatched = ?, enroute = ?, on_scene = ?, clear = ?,
-			narrative = ?, updated_at = CURRENT_TIMESTAMP
+			narrative = ?, incident_type_code = ?, address_key = ?,
+			weather_temp_f = ?, weather_wind = ?, weather_precipitation = ?, road_conditions = ?,
+			updated_at = CURRENT_TIMESTAMP
 		WHERE id = ?
 	`, call.IncidentNumber, call.CallType, call.MutualAid,
 		call.Address, call.Town, call.LocationNotes,
 		call.Dispatched, call.Enroute, call.OnScene, call.Clear,
-		call.Narrative, call.ID)
+		call.Narrative, call.IncidentTypeCode, call.AddressKey,
+		call.TemperatureF, call.Wind, call.Precipitation, call.RoadConditions, call.ID)
 	
 	if err != nil {
 		return err
@@ -354,6 +309,10 @@ func (db *DB) UpdateCall(call *Call, apparatusIDs []int, responderIDs []int, res
 		}
 	}
 
+	if err := deleteUnassignedUsage(tx, call.ID); err != nil {
+		return err
+	}
+
 	// Re-insert responders
 	for i, responderID := range responderIDs {
 		role := ""
@@ -369,6 +328,35 @@ func (db *DB) UpdateCall(call *Call, apparatusIDs []int, responderIDs []int, res
 		}
 	}
 
+	if err := saveCustomValues(tx, call.ID, call.CustomFields); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// ClearCallAssignments removes every apparatus and responder from a call.
+// Unlike UpdateCall it does not check required fields, so it works however
+// the call form is configured.
+func (db *DB) ClearCallAssignments(callID int) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec("DELETE FROM call_apparatus WHERE call_id = ?", callID); err != nil {
+		return err
+	}
+	if _, err := tx.Exec("DELETE FROM call_responders WHERE call_id = ?", callID); err != nil {
+		return err
+	}
+	if err := deleteUnassignedUsage(tx, callID); err != nil {
+		return err
+	}
+	if _, err := tx.Exec("UPDATE calls SET updated_at = CURRENT_TIMESTAMP WHERE id = ?", callID); err != nil {
+		return err
+	}
 	return tx.Commit()
 }
 
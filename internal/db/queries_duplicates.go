@@ -0,0 +1,94 @@
+package db
+
+import (
+	"time"
+)
+
+// defaultDuplicateWindowMinutes is used when duplicate_window_minutes is not set
+const defaultDuplicateWindowMinutes = 120
+
+// GetDuplicateWindow returns the configured time window around Dispatched
+// used to look for duplicate calls
+func (db *DB) GetDuplicateWindow() time.Duration {
+	minutes := db.GetIntSetting("duplicate_window_minutes", defaultDuplicateWindowMinutes)
+	return time.Duration(minutes) * time.Minute
+}
+
+// FindPossibleDuplicates returns existing calls dispatched within window of
+// call that are at the same normalized address or of the same call type.
+// The call itself is excluded when it has already been saved.
+func (db *DB) FindPossibleDuplicates(call *Call, window time.Duration) ([]Call, error) {
+	addressKey := NormalizeAddress(call.Address)
+
+	rows, err := db.Query(`
+		SELECT `+callColumns+`
+		FROM calls
+		WHERE dispatched >= ? AND dispatched <= ?
+		AND id != ?
+		AND ((address_key = ? AND address_key != '') OR call_type = ?)
+		ORDER BY dispatched
+	`, call.Dispatched.Add(-window), call.Dispatched.Add(window), call.ID, addressKey, call.CallType)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var calls []Call
+	for rows.Next() {
+		var c Call
+		if err := scanCall(rows, &c); err != nil {
+			return nil, err
+		}
+		calls = append(calls, c)
+	}
+	return calls, rows.Err()
+}
+
+// FindLikelyDuplicates scans every call for pairs dispatched within window
+// of each other at the same normalized address or of the same call type
+func (db *DB) FindLikelyDuplicates(window time.Duration) ([]DuplicatePair, error) {
+	rows, err := db.Query(`
+		SELECT ` + callColumns + `
+		FROM calls
+		ORDER BY dispatched, id
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var calls []Call
+	for rows.Next() {
+		var c Call
+		if err := scanCall(rows, &c); err != nil {
+			return nil, err
+		}
+		calls = append(calls, c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	var pairs []DuplicatePair
+	for i := range calls {
+		for j := i + 1; j < len(calls); j++ {
+			if calls[j].Dispatched.Sub(calls[i].Dispatched) > window {
+				break
+			}
+			sameAddress := calls[i].AddressKey != "" && calls[i].AddressKey == calls[j].AddressKey
+			sameType := calls[i].CallType == calls[j].CallType
+			if !sameAddress && !sameType {
+				continue
+			}
+
+			reason := "same call type"
+			if sameAddress && sameType {
+				reason = "same address and call type"
+			} else if sameAddress {
+				reason = "same address"
+			}
+			pairs = append(pairs, DuplicatePair{First: calls[i], Second: calls[j], Reason: reason})
+		}
+	}
+	return pairs, nil
+}
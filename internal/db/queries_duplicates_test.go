@@ -0,0 +1,59 @@
+package db
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFindPossibleDuplicates(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	base := time.Date(2026, 3, 5, 9, 0, 0, 0, time.UTC)
+	sameAddress := createTestCall(t, db, "Alarm Investigation", "22 Church Street", base)
+	createTestCall(t, db, "Medical Emergency", "1 Other Rd", base.Add(30*time.Minute))
+	createTestCall(t, db, "Alarm Investigation", "22 Church St", base.Add(5*time.Hour))
+
+	draft := &Call{
+		CallType:   "Structure Fire",
+		Address:    "22 church st.",
+		Dispatched: base.Add(45 * time.Minute),
+	}
+	duplicates, err := db.FindPossibleDuplicates(draft, 2*time.Hour)
+	if err != nil {
+		t.Fatalf("Failed to find duplicates: %v", err)
+	}
+	if len(duplicates) != 1 || duplicates[0].ID != sameAddress.ID {
+		t.Fatalf("Expected only call %d as duplicate, got %+v", sameAddress.ID, duplicates)
+	}
+
+	draft.CallType = "Medical Emergency"
+	duplicates, err = db.FindPossibleDuplicates(draft, 2*time.Hour)
+	if err != nil {
+		t.Fatalf("Failed to find duplicates: %v", err)
+	}
+	if len(duplicates) != 2 {
+		t.Errorf("Expected address and call type matches, got %d", len(duplicates))
+	}
+}
+
+func TestFindLikelyDuplicates(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	base := time.Date(2026, 3, 5, 9, 0, 0, 0, time.UTC)
+	createTestCall(t, db, "Structure Fire", "5 Pine Lane", base)
+	createTestCall(t, db, "Structure Fire", "5 Pine Ln", base.Add(20*time.Minute))
+	createTestCall(t, db, "Structure Fire", "5 Pine Ln", base.Add(24*time.Hour))
+
+	pairs, err := db.FindLikelyDuplicates(time.Hour)
+	if err != nil {
+		t.Fatalf("Failed to find likely duplicates: %v", err)
+	}
+	if len(pairs) != 1 {
+		t.Fatalf("Expected 1 duplicate pair, got %d", len(pairs))
+	}
+	if pairs[0].Reason != "same address and call type" {
+		t.Errorf("Unexpected reason: %s", pairs[0].Reason)
+	}
+}
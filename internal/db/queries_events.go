@@ -0,0 +1,397 @@
+package db
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// eventColumns is the column list read by scanEvent
+const eventColumns = `id, event_number, event_type, topic, instructor, location,
+		       start_time, hours, notes, created_by, created_at, updated_at`
+
+// scanEvent scans a row selected with eventColumns into event
+func scanEvent(row rowScanner, event *Event) error {
+	var createdBy sql.NullInt64
+	err := row.Scan(&event.ID, &event.EventNumber, &event.EventType, &event.Topic,
+		&event.Instructor, &event.Location, &event.StartTime, &event.Hours,
+		&event.Notes, &createdBy, &event.CreatedAt, &event.UpdatedAt)
+	event.CreatedBy = int(createdBy.Int64)
+	return err
+}
+
+// GetNextEventNumber generates the next event number for the given year.
+// Events are numbered separately from calls.
+// Format: TYYYY-NNN (e.g., T2026-001, T2026-002, etc.)
+func (db *DB) GetNextEventNumber(year int) (string, error) {
+	prefix := fmt.Sprintf("T%d-", year)
+
+	var maxNumber int
+	err := db.QueryRow(`
+		SELECT COALESCE(MAX(CAST(SUBSTR(event_number, 7) AS INTEGER)), 0)
+		FROM events
+		WHERE event_number LIKE ? || '%'
+		AND LENGTH(event_number) = 9
+	`, prefix).Scan(&maxNumber)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%s%03d", prefix, maxNumber+1), nil
+}
+
+// ensureTrainingEvents retires the Training call type seeded by earlier
+// releases and moves Training calls into events, with their responders as
+// attendance, so drills drop out of call numbering and statistics
+// (migration for existing databases)
+func (db *DB) ensureTrainingEvents() error {
+	_, err := db.Exec("UPDATE picklists SET active = 0 WHERE category = 'call_type' AND value = 'Training'")
+	if err != nil {
+		return err
+	}
+
+	rows, err := db.Query(`
+		SELECT ` + callColumns + `
+		FROM calls WHERE call_type = 'Training'
+		ORDER BY dispatched, id
+	`)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	var calls []Call
+	for rows.Next() {
+		var call Call
+		if err := scanCall(rows, &call); err != nil {
+			return err
+		}
+		calls = append(calls, call)
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	rows.Close()
+	if len(calls) == 0 {
+		return nil
+	}
+
+	// Numbers are handed out here because GetNextEventNumber cannot see
+	// events inserted by the transaction
+	next := make(map[int]int)
+	for _, call := range calls {
+		year := call.Dispatched.Year()
+		if _, ok := next[year]; ok {
+			continue
+		}
+		number, err := db.GetNextEventNumber(year)
+		if err != nil {
+			return err
+		}
+		var seq int
+		if _, err := fmt.Sscanf(number, fmt.Sprintf("T%d-%%d", year), &seq); err != nil {
+			return err
+		}
+		next[year] = seq
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for _, call := range calls {
+		year := call.Dispatched.Year()
+		number := fmt.Sprintf("T%d-%03d", year, next[year])
+		next[year]++
+
+		topic := strings.TrimSpace(strings.SplitN(call.Narrative, "\n", 2)[0])
+		if topic == "" {
+			topic = "Training"
+		}
+		location := call.Address
+		if call.Town != "" {
+			location += ", " + call.Town
+		}
+		var hours float64
+		if call.Clear != nil && call.Clear.After(call.Dispatched) {
+			hours = call.Clear.Sub(call.Dispatched).Hours()
+		}
+		notes := strings.TrimSpace(fmt.Sprintf("Moved from call %s\n\n%s", call.IncidentNumber, call.Narrative))
+		var createdBy interface{}
+		if call.CreatedBy > 0 {
+			createdBy = call.CreatedBy
+		}
+
+		result, err := tx.Exec(`
+			INSERT INTO events (
+				event_number, event_type, topic, location, start_time, hours, notes, created_by
+			) VALUES (?, 'Training', ?, ?, ?, ?, ?, ?)
+		`, number, topic, location, call.Dispatched, hours, notes, createdBy)
+		if err != nil {
+			return err
+		}
+		eventID, err := result.LastInsertId()
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.Exec(`
+			INSERT OR IGNORE INTO event_attendance (event_id, user_id)
+			SELECT ?, responder_id FROM call_responders WHERE call_id = ?
+		`, eventID, call.ID)
+		if err != nil {
+			return err
+		}
+		if _, err := tx.Exec("DELETE FROM calls WHERE id = ?", call.ID); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// validateEvent checks the fields of an event before it is saved
+func (db *DB) validateEvent(event *Event) error {
+	if strings.TrimSpace(event.Topic) == "" {
+		return errors.New("event topic is required")
+	}
+	if event.EventType == "" {
+		return errors.New("event type is required")
+	}
+	if event.StartTime.IsZero() {
+		return errors.New("event start time is required")
+	}
+	if event.Hours < 0 {
+		return errors.New("event hours cannot be negative")
+	}
+	return db.validatePicklistValue("event_type", event.EventType)
+}
+
+// CreateEvent creates an event and records its attendance. An event number
+// is generated from the start time when one is not given.
+func (db *DB) CreateEvent(event *Event, attendeeIDs []int) error {
+	if err := db.validateEvent(event); err != nil {
+		return err
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if event.EventNumber == "" {
+		event.EventNumber, err = db.GetNextEventNumber(event.StartTime.Year())
+		if err != nil {
+			return err
+		}
+	}
+
+	var createdBy interface{}
+	if event.CreatedBy > 0 {
+		createdBy = event.CreatedBy
+	}
+
+	result, err := tx.Exec(`
+		INSERT INTO events (
+			event_number, event_type, topic, instructor, location,
+			start_time, hours, notes, created_by
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`, event.EventNumber, event.EventType, event.Topic, event.Instructor, event.Location,
+		event.StartTime, event.Hours, event.Notes, createdBy)
+	if err != nil {
+		return err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+	event.ID = int(id)
+
+	if err := saveAttendance(tx, event.ID, attendeeIDs); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// UpdateEvent updates an event and replaces its attendance
+func (db *DB) UpdateEvent(event *Event, attendeeIDs []int) error {
+	if err := db.validateEvent(event); err != nil {
+		return err
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.Exec(`
+		UPDATE events SET
+			event_type = ?, topic = ?, instructor = ?, location = ?,
+			start_time = ?, hours = ?, notes = ?, updated_at = CURRENT_TIMESTAMP
+		WHERE id = ?
+	`, event.EventType, event.Topic, event.Instructor, event.Location,
+		event.StartTime, event.Hours, event.Notes, event.ID)
+	if err != nil {
+		return err
+	}
+
+	if err := saveAttendance(tx, event.ID, attendeeIDs); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// saveAttendance replaces the members recorded as attending an event
+func saveAttendance(tx *sql.Tx, eventID int, attendeeIDs []int) error {
+	if _, err := tx.Exec("DELETE FROM event_attendance WHERE event_id = ?", eventID); err != nil {
+		return err
+	}
+	for _, userID := range attendeeIDs {
+		_, err := tx.Exec(`
+			INSERT OR IGNORE INTO event_attendance (event_id, user_id) VALUES (?, ?)
+		`, eventID, userID)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DeleteEvent removes an event and its attendance
+func (db *DB) DeleteEvent(id int) error {
+	_, err := db.Exec("DELETE FROM events WHERE id = ?", id)
+	return err
+}
+
+// GetEventByID returns an event with its attendees, or nil if it does not exist
+func (db *DB) GetEventByID(id int) (*Event, error) {
+	var event Event
+	err := scanEvent(db.QueryRow(`
+		SELECT `+eventColumns+`
+		FROM events WHERE id = ?
+	`, id), &event)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := db.Query(`
+		SELECT u.id, u.first_name, u.last_name, u.position, u.ems_level, u.active, u.created
+		FROM event_attendance ea
+		JOIN users u ON ea.user_id = u.id
+		WHERE ea.event_id = ?
+		ORDER BY u.last_name, u.first_name
+	`, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var user User
+		var emsLevel sql.NullString
+		if err := rows.Scan(&user.ID, &user.FirstName, &user.LastName, &user.Position, &emsLevel, &user.Active, &user.Created); err != nil {
+			return nil, err
+		}
+		user.EMSLevel = emsLevel.String
+		event.Attendees = append(event.Attendees, user)
+	}
+	return &event, rows.Err()
+}
+
+// GetRecentEvents returns a page of events, most recent first
+func (db *DB) GetRecentEvents(cursor string, limit int) (*EventPage, error) {
+	return db.SearchEvents(EventFilter{}, cursor, limit)
+}
+
+// where builds the SQL conditions (each starting with AND) and arguments
+// for an event filter
+func (f EventFilter) where() (string, []interface{}) {
+	var where string
+	var args []interface{}
+
+	if f.StartFrom != nil {
+		where += " AND start_time >= ?"
+		args = append(args, *f.StartFrom)
+	}
+	if f.StartTo != nil {
+		where += " AND start_time <= ?"
+		args = append(args, *f.StartTo)
+	}
+	if f.EventType != "" {
+		where += " AND event_type = ?"
+		args = append(args, f.EventType)
+	}
+	if f.AttendeeID != 0 {
+		where += " AND id IN (SELECT event_id FROM event_attendance WHERE user_id = ?)"
+		args = append(args, f.AttendeeID)
+	}
+	if f.Text != "" {
+		where += " AND (event_number LIKE ? OR topic LIKE ? OR instructor LIKE ?)"
+		pattern := "%" + f.Text + "%"
+		args = append(args, pattern, pattern, pattern)
+	}
+	return where, args
+}
+
+// SearchEvents returns a page of the events matching filter, most recent
+// first. Pass "" as cursor for the first page.
+func (db *DB) SearchEvents(filter EventFilter, cursor string, limit int) (*EventPage, error) {
+	afterID, err := keysetCursor(cursor)
+	if err != nil {
+		return nil, err
+	}
+	limit = pageSize(limit)
+
+	where, args := filter.where()
+	page := &EventPage{}
+	if err := db.QueryRow("SELECT COUNT(*) FROM events WHERE 1=1"+where, args...).Scan(&page.Total); err != nil {
+		return nil, err
+	}
+
+	if afterID != 0 {
+		after, err := db.afterRow("events", "start_time", "<", afterID)
+		if err != nil {
+			return nil, err
+		}
+		where += after
+		args = append(args, afterID)
+	}
+
+	query := `
+		SELECT ` + eventColumns + `
+		FROM events
+		WHERE 1=1
+	` + where + " ORDER BY start_time DESC, id DESC LIMIT ?"
+	args = append(args, limit+1)
+
+	rows, err := db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var event Event
+		if err := scanEvent(rows, &event); err != nil {
+			return nil, err
+		}
+		page.Items = append(page.Items, event)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	var more bool
+	if page.Items, more = trimPage(page.Items, limit); more {
+		page.NextCursor = fmt.Sprintf("after:%d", page.Items[limit-1].ID)
+	}
+	return page, nil
+}
@@ -0,0 +1,164 @@
+package db
+
+import (
+	"testing"
+	"time"
+)
+
+func TestEventNumberingSeparateFromCalls(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	start := time.Date(2026, 3, 4, 19, 0, 0, 0, time.Local)
+	createTestCall(t, db, "Structure Fire", "1 Elm St", start)
+
+	event := &Event{EventType: "Drill", Topic: "Ladder operations", Instructor: "Capt. Reyes", StartTime: start, Hours: 2.5}
+	if err := db.CreateEvent(event, []int{1}); err != nil {
+		t.Fatalf("Failed to create event: %v", err)
+	}
+	if event.EventNumber != "T2026-001" {
+		t.Errorf("Expected T2026-001, got %s", event.EventNumber)
+	}
+
+	next, err := db.GetNextEventNumber(2026)
+	if err != nil {
+		t.Fatalf("Failed to get next event number: %v", err)
+	}
+	if next != "T2026-002" {
+		t.Errorf("Expected T2026-002, got %s", next)
+	}
+
+	callNumber, err := db.GetNextCallNumber(2026)
+	if err != nil {
+		t.Fatalf("Failed to get next call number: %v", err)
+	}
+	if callNumber != "2026-002" {
+		t.Errorf("Events should not affect call numbering, got %s", callNumber)
+	}
+
+	calls, err := db.GetCallsByYear(2026, "", 10)
+	if err != nil {
+		t.Fatalf("Failed to get calls: %v", err)
+	}
+	if calls.Total != 1 || len(calls.Items) != 1 {
+		t.Errorf("Expected only the emergency call, got %d calls", calls.Total)
+	}
+}
+
+func TestEventAttendanceAndSearch(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	if err := db.CreateUser("Dana", "Smith", "member", "EMT", "1234", false); err != nil {
+		t.Fatalf("Failed to create user: %v", err)
+	}
+	users, err := db.GetAllUsers()
+	if err != nil {
+		t.Fatalf("Failed to get users: %v", err)
+	}
+	var ids []int
+	for _, u := range users {
+		ids = append(ids, u.ID)
+	}
+	if len(ids) < 2 {
+		t.Fatalf("Expected at least 2 users, got %d", len(ids))
+	}
+
+	training := &Event{EventType: "Training", Topic: "CPR recertification", StartTime: time.Now(), Hours: 4}
+	if err := db.CreateEvent(training, ids); err != nil {
+		t.Fatalf("Failed to create training: %v", err)
+	}
+	meeting := &Event{EventType: "Meeting", Topic: "Monthly business meeting", StartTime: time.Now(), Hours: 1}
+	if err := db.CreateEvent(meeting, ids[:1]); err != nil {
+		t.Fatalf("Failed to create meeting: %v", err)
+	}
+
+	saved, err := db.GetEventByID(training.ID)
+	if err != nil {
+		t.Fatalf("Failed to get event: %v", err)
+	}
+	if len(saved.Attendees) != len(ids) {
+		t.Errorf("Expected %d attendees, got %d", len(ids), len(saved.Attendees))
+	}
+
+	found, err := db.SearchEvents(EventFilter{Text: "CPR"}, "", 10)
+	if err != nil {
+		t.Fatalf("Failed to search events: %v", err)
+	}
+	if len(found.Items) != 1 || found.Items[0].ID != training.ID {
+		t.Errorf("Expected the CPR training, got %d events", len(found.Items))
+	}
+
+	attended, err := db.SearchEvents(EventFilter{AttendeeID: ids[len(ids)-1]}, "", 10)
+	if err != nil {
+		t.Fatalf("Failed to search by attendee: %v", err)
+	}
+	if attended.Total != 1 || len(attended.Items) != 1 || attended.Items[0].ID != training.ID {
+		t.Errorf("Expected 1 event attended, got %d", attended.Total)
+	}
+
+	bad := &Event{EventType: "Party", Topic: "Picnic", StartTime: time.Now()}
+	if err := db.CreateEvent(bad, nil); err == nil {
+		t.Error("Expected error for event type not in picklist")
+	}
+}
+
+func TestTrainingCallsMovedToEvents(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	// Databases created before events seeded Training as a call type
+	if _, err := db.Exec("INSERT OR IGNORE INTO picklists (category, value, sort_order, active) VALUES ('call_type', 'Training', 99, 1)"); err != nil {
+		t.Fatalf("Failed to seed legacy call type: %v", err)
+	}
+	dispatched := time.Date(2026, 4, 7, 19, 0, 0, 0, time.Local)
+	drill := createTestCall(t, db, "Training", "Station 1", dispatched)
+	clear := dispatched.Add(2 * time.Hour)
+	drill.Clear = &clear
+	drill.Narrative = "Pump operations\nDrafting from the pond"
+	if err := db.UpdateCall(drill, nil, []int{1}, nil); err != nil {
+		t.Fatalf("Failed to update training call: %v", err)
+	}
+	fire := createTestCall(t, db, "Structure Fire", "1 Elm St", dispatched)
+
+	if err := db.ensureTrainingEvents(); err != nil {
+		t.Fatalf("Failed to move training calls: %v", err)
+	}
+
+	if call, _, _, err := db.GetCallByID(drill.ID); err == nil && call != nil {
+		t.Error("Expected the training call to be removed from calls")
+	}
+	if _, _, _, err := db.GetCallByID(fire.ID); err != nil {
+		t.Errorf("Expected other calls to be kept, got %v", err)
+	}
+	if err := db.validatePicklistValue("call_type", "Training"); err == nil {
+		t.Error("Expected the Training call type to be deactivated")
+	}
+
+	events, err := db.SearchEvents(EventFilter{EventType: "Training"}, "", 10)
+	if err != nil {
+		t.Fatalf("Failed to list events: %v", err)
+	}
+	if len(events.Items) != 1 {
+		t.Fatalf("Expected 1 training event, got %d", len(events.Items))
+	}
+	event, err := db.GetEventByID(events.Items[0].ID)
+	if err != nil {
+		t.Fatalf("Failed to get event: %v", err)
+	}
+	if event.EventNumber != "T2026-001" || event.Topic != "Pump operations" || event.Hours != 2 {
+		t.Errorf("Unexpected event %+v", event)
+	}
+	if len(event.Attendees) != 1 || event.Attendees[0].ID != 1 {
+		t.Errorf("Expected the responder as the only attendee, got %+v", event.Attendees)
+	}
+
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.Local)
+	stats, err := db.GetCallStats(start, start.AddDate(1, 0, 0).Add(-time.Nanosecond), CallFilter{})
+	if err != nil {
+		t.Fatalf("Failed to get stats: %v", err)
+	}
+	if stats.Total.Current != 1 {
+		t.Errorf("Expected only the fire in call stats, got %d", stats.Total.Current)
+	}
+}
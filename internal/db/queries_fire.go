@@ -0,0 +1,114 @@
+package db
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+)
+
+// ensureFirePicklists seeds the picklists used by fire detail records
+func (db *DB) ensureFirePicklists() error {
+	categories := []struct {
+		category string
+		values   []string
+	}{
+		{"fire_area_of_origin", []string{"Kitchen/Cooking Area", "Bedroom", "Living/Family Room", "Bathroom", "Basement/Cellar", "Attic", "Garage", "Chimney/Flue", "Heating Equipment Room", "Exterior Wall/Roof", "Porch/Deck", "Vehicle Engine Area", "Vehicle Passenger Area", "Vehicle Cargo Area", "Other", "Undetermined"}},
+		{"fire_heat_source", []string{"Operating Equipment", "Electrical Arcing", "Hot Ember or Ash", "Open Flame (Candle, Match, Lighter)", "Smoking Materials", "Chemical/Natural Heat Source", "Lightning", "Spread from Another Fire", "Other", "Undetermined"}},
+		{"fire_cause", []string{"Intentional", "Unintentional", "Equipment Failure", "Act of Nature", "Under Investigation", "Undetermined"}},
+		{"detector_presence", []string{"Present", "None Present", "Undetermined"}},
+	}
+
+	for _, c := range categories {
+		if err := db.ensurePicklistCategory(c.category, c.values); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SaveFireDetails creates or replaces the fire details for a fire call
+func (db *DB) SaveFireDetails(details *FireDetails) error {
+	if err := db.requireIncidentSeries(details.CallID, '1', "fire details"); err != nil {
+		return err
+	}
+	if details.PropertyLoss < 0 || details.ContentsLoss < 0 || details.ValueSaved < 0 {
+		return errors.New("loss and value saved amounts cannot be negative")
+	}
+
+	fields := []struct {
+		category string
+		value    string
+	}{
+		{"fire_area_of_origin", details.AreaOfOrigin},
+		{"fire_heat_source", details.HeatSource},
+		{"fire_cause", details.CauseCategory},
+		{"detector_presence", details.DetectorsPresent},
+	}
+	for _, f := range fields {
+		if err := db.validatePicklistValue(f.category, f.value); err != nil {
+			return err
+		}
+	}
+
+	_, err := db.Exec(`
+		INSERT INTO fire_details (
+			call_id, area_of_origin, heat_source, cause_category,
+			property_loss, contents_loss, value_saved, detectors_present, notes, updated_at
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, CURRENT_TIMESTAMP)
+		ON CONFLICT(call_id) DO UPDATE SET
+			area_of_origin = excluded.area_of_origin,
+			heat_source = excluded.heat_source,
+			cause_category = excluded.cause_category,
+			property_loss = excluded.property_loss,
+			contents_loss = excluded.contents_loss,
+			value_saved = excluded.value_saved,
+			detectors_present = excluded.detectors_present,
+			notes = excluded.notes,
+			updated_at = CURRENT_TIMESTAMP
+	`, details.CallID, details.AreaOfOrigin, details.HeatSource, details.CauseCategory,
+		details.PropertyLoss, details.ContentsLoss, details.ValueSaved, details.DetectorsPresent, details.Notes)
+	return err
+}
+
+// GetFireDetails returns the fire details for a call, or nil if none were recorded
+func (db *DB) GetFireDetails(callID int) (*FireDetails, error) {
+	var d FireDetails
+	err := db.QueryRow(`
+		SELECT call_id, area_of_origin, heat_source, cause_category,
+		       property_loss, contents_loss, value_saved, detectors_present, notes, updated_at
+		FROM fire_details WHERE call_id = ?
+	`, callID).Scan(&d.CallID, &d.AreaOfOrigin, &d.HeatSource, &d.CauseCategory,
+		&d.PropertyLoss, &d.ContentsLoss, &d.ValueSaved, &d.DetectorsPresent, &d.Notes, &d.UpdatedAt)
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &d, nil
+}
+
+// DeleteFireDetails removes the fire details for a call
+func (db *DB) DeleteFireDetails(callID int) error {
+	_, err := db.Exec("DELETE FROM fire_details WHERE call_id = ?", callID)
+	return err
+}
+
+// GetFireLossTotals sums fire losses for calls dispatched between start and end
+func (db *DB) GetFireLossTotals(start, end time.Time) (*FireLossTotals, error) {
+	var totals FireLossTotals
+	err := db.QueryRow(`
+		SELECT COUNT(*),
+		       COALESCE(SUM(f.property_loss), 0),
+		       COALESCE(SUM(f.contents_loss), 0),
+		       COALESCE(SUM(f.value_saved), 0)
+		FROM fire_details f
+		JOIN calls c ON c.id = f.call_id
+		WHERE c.dispatched >= ? AND c.dispatched <= ?
+	`, start, end).Scan(&totals.Fires, &totals.PropertyLoss, &totals.ContentsLoss, &totals.ValueSaved)
+	if err != nil {
+		return nil, err
+	}
+	return &totals, nil
+}
@@ -0,0 +1,163 @@
+package db
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSaveFireDetailsUpserts(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	call := createTestCall(t, db, "Structure Fire", "12 Main St", time.Now())
+	details := &FireDetails{
+		CallID:           call.ID,
+		AreaOfOrigin:     "Kitchen/Cooking Area",
+		HeatSource:       "Operating Equipment",
+		CauseCategory:    "Unintentional",
+		PropertyLoss:     40000,
+		ContentsLoss:     15000,
+		ValueSaved:       250000,
+		DetectorsPresent: "Present",
+	}
+	if err := db.SaveFireDetails(details); err != nil {
+		t.Fatalf("Failed to save fire details: %v", err)
+	}
+
+	details.CauseCategory = "Under Investigation"
+	details.PropertyLoss = 60000
+	if err := db.SaveFireDetails(details); err != nil {
+		t.Fatalf("Failed to update fire details: %v", err)
+	}
+
+	saved, err := db.GetFireDetails(call.ID)
+	if err != nil {
+		t.Fatalf("Failed to get fire details: %v", err)
+	}
+	if saved == nil || saved.CauseCategory != "Under Investigation" || saved.PropertyLoss != 60000 {
+		t.Errorf("Expected the second save to replace the first, got %+v", saved)
+	}
+	if saved.TotalLoss() != 75000 {
+		t.Errorf("Expected total loss 75000, got %d", saved.TotalLoss())
+	}
+
+	var count int
+	if err := db.QueryRow("SELECT COUNT(*) FROM fire_details WHERE call_id = ?", call.ID).Scan(&count); err != nil {
+		t.Fatalf("Failed to count fire details: %v", err)
+	}
+	if count != 1 {
+		t.Errorf("Expected one fire details row, got %d", count)
+	}
+}
+
+func TestSaveFireDetailsValidation(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	call := createTestCall(t, db, "Vehicle Fire", "Route 100", time.Now())
+
+	bad := []FireDetails{
+		{CallID: call.ID, AreaOfOrigin: "Spaceship"},
+		{CallID: call.ID, HeatSource: "Dragon"},
+		{CallID: call.ID, CauseCategory: "Unknown"},
+		{CallID: call.ID, DetectorsPresent: "Maybe"},
+		{CallID: call.ID, PropertyLoss: -1},
+	}
+	for _, details := range bad {
+		if err := db.SaveFireDetails(&details); err == nil {
+			t.Errorf("Expected error for %+v", details)
+		}
+	}
+
+	// Every field is optional
+	if err := db.SaveFireDetails(&FireDetails{CallID: call.ID}); err != nil {
+		t.Errorf("Expected empty details to save, got %v", err)
+	}
+}
+
+func TestDeleteFireDetails(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	call := createTestCall(t, db, "Structure Fire", "12 Main St", time.Now())
+	if err := db.SaveFireDetails(&FireDetails{CallID: call.ID, PropertyLoss: 1000}); err != nil {
+		t.Fatalf("Failed to save fire details: %v", err)
+	}
+	if err := db.DeleteFireDetails(call.ID); err != nil {
+		t.Fatalf("Failed to delete fire details: %v", err)
+	}
+
+	saved, err := db.GetFireDetails(call.ID)
+	if err != nil {
+		t.Fatalf("Failed to get fire details: %v", err)
+	}
+	if saved != nil {
+		t.Errorf("Expected no fire details after delete, got %+v", saved)
+	}
+}
+
+func TestGetFireLossTotals(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	march := time.Date(2026, 3, 10, 12, 0, 0, 0, time.Local)
+	for _, f := range []struct {
+		dispatched time.Time
+		details    FireDetails
+	}{
+		{march, FireDetails{PropertyLoss: 40000, ContentsLoss: 10000, ValueSaved: 200000}},
+		{march.AddDate(0, 0, 5), FireDetails{PropertyLoss: 5000, ValueSaved: 1000}},
+		{march.AddDate(0, 1, 0), FireDetails{PropertyLoss: 99999}}, // outside the period
+	} {
+		call := createTestCall(t, db, "Structure Fire", "12 Main St", f.dispatched)
+		f.details.CallID = call.ID
+		if err := db.SaveFireDetails(&f.details); err != nil {
+			t.Fatalf("Failed to save fire details: %v", err)
+		}
+	}
+	// Calls without fire details are not counted
+	createTestCall(t, db, "Medical Emergency", "3 Elm St", march)
+
+	start := time.Date(2026, 3, 1, 0, 0, 0, 0, time.Local)
+	totals, err := db.GetFireLossTotals(start, start.AddDate(0, 1, 0).Add(-time.Nanosecond))
+	if err != nil {
+		t.Fatalf("Failed to get loss totals: %v", err)
+	}
+	want := FireLossTotals{Fires: 2, PropertyLoss: 45000, ContentsLoss: 10000, ValueSaved: 201000}
+	if *totals != want {
+		t.Errorf("Expected %+v, got %+v", want, *totals)
+	}
+	if totals.TotalLoss() != 55000 {
+		t.Errorf("Expected total loss 55000, got %d", totals.TotalLoss())
+	}
+
+	empty, err := db.GetFireLossTotals(start.AddDate(-1, 0, 0), start.AddDate(-1, 1, 0))
+	if err != nil {
+		t.Fatalf("Failed to get empty loss totals: %v", err)
+	}
+	if empty.Fires != 0 || empty.TotalLoss() != 0 {
+		t.Errorf("Expected no losses for an empty period, got %+v", empty)
+	}
+}
+
+func TestSaveFireDetailsOnlyFireCalls(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	call := createTestCall(t, db, "Medical Emergency", "3 Elm St", time.Now())
+	if err := db.SaveFireDetails(&FireDetails{CallID: call.ID}); err == nil {
+		t.Error("Expected error saving fire details on a medical call")
+	}
+	if err := db.SaveFireDetails(&FireDetails{CallID: 9999}); err == nil {
+		t.Error("Expected error saving fire details on a missing call")
+	}
+
+	// A call's own incident code overrides its call type
+	call.IncidentTypeCode = "113"
+	if _, err := db.Exec("UPDATE calls SET incident_type_code = ? WHERE id = ?", call.IncidentTypeCode, call.ID); err != nil {
+		t.Fatalf("Failed to set incident code: %v", err)
+	}
+	if err := db.SaveFireDetails(&FireDetails{CallID: call.ID}); err != nil {
+		t.Errorf("Expected fire details on a cooking fire call, got %v", err)
+	}
+}
@@ -0,0 +1,318 @@
+package db
+
+import (
+	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Custom field types
+const (
+	CustomFieldText     = "text"
+	CustomFieldNumber   = "number"
+	CustomFieldPicklist = "picklist"
+	CustomFieldCheckbox = "checkbox"
+	CustomFieldDatetime = "datetime"
+)
+
+// GetFormFields returns the call form configuration in display order
+func (db *DB) GetFormFields() ([]FormField, error) {
+	rows, err := db.Query(`
+		SELECT id, field_name, label, required, enabled, sort_order
+		FROM form_fields
+		ORDER BY sort_order, field_name
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var fields []FormField
+	for rows.Next() {
+		var f FormField
+		if err := rows.Scan(&f.ID, &f.FieldName, &f.Label, &f.Required, &f.Enabled, &f.SortOrder); err != nil {
+			return nil, err
+		}
+		fields = append(fields, f)
+	}
+	return fields, nil
+}
+
+// UpdateFormField updates the label, required/enabled flags and order of a form field
+func (db *DB) UpdateFormField(field *FormField) error {
+	_, err := db.Exec(`
+		UPDATE form_fields
+		SET label = ?, required = ?, enabled = ?, sort_order = ?
+		WHERE id = ?
+	`, field.Label, field.Required, field.Enabled, field.SortOrder, field.ID)
+	return err
+}
+
+// GetCustomFields returns the active department-specific fields in display order
+func (db *DB) GetCustomFields() ([]CustomField, error) {
+	return db.queryCustomFields("WHERE active = 1")
+}
+
+// GetAllCustomFields returns every custom field, including inactive ones (for admin)
+func (db *DB) GetAllCustomFields() ([]CustomField, error) {
+	return db.queryCustomFields("")
+}
+
+func (db *DB) queryCustomFields(where string) ([]CustomField, error) {
+	rows, err := db.Query(`
+		SELECT id, field_name, label, field_type, picklist_category, required, active, sort_order
+		FROM custom_fields ` + where + `
+		ORDER BY sort_order, label
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var fields []CustomField
+	for rows.Next() {
+		var f CustomField
+		err := rows.Scan(&f.ID, &f.FieldName, &f.Label, &f.FieldType, &f.PicklistCategory, &f.Required, &f.Active, &f.SortOrder)
+		if err != nil {
+			return nil, err
+		}
+		fields = append(fields, f)
+	}
+	return fields, nil
+}
+
+// CreateCustomField defines a new department-specific call field
+func (db *DB) CreateCustomField(field *CustomField) error {
+	if err := validateCustomFieldDefinition(field); err != nil {
+		return err
+	}
+
+	result, err := db.Exec(`
+		INSERT INTO custom_fields (field_name, label, field_type, picklist_category, required, active, sort_order)
+		VALUES (?, ?, ?, ?, ?, 1, ?)
+	`, field.FieldName, field.Label, field.FieldType, field.PicklistCategory, field.Required, field.SortOrder)
+	if err != nil {
+		return fmt.Errorf("failed to create custom field: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+	field.ID = int(id)
+	field.Active = true
+	return nil
+}
+
+// UpdateCustomField updates a custom field definition. The field name is
+// fixed once created because stored values refer to the field by ID.
+func (db *DB) UpdateCustomField(field *CustomField) error {
+	if err := validateCustomFieldDefinition(field); err != nil {
+		return err
+	}
+
+	_, err := db.Exec(`
+		UPDATE custom_fields
+		SET label = ?, field_type = ?, picklist_category = ?, required = ?, active = ?, sort_order = ?
+		WHERE id = ?
+	`, field.Label, field.FieldType, field.PicklistCategory, field.Required, field.Active, field.SortOrder, field.ID)
+	return err
+}
+
+// DeleteCustomField soft-deletes a custom field (sets active = false).
+// Values already stored on calls are kept.
+func (db *DB) DeleteCustomField(id int) error {
+	_, err := db.Exec("UPDATE custom_fields SET active = 0 WHERE id = ?", id)
+	return err
+}
+
+func validateCustomFieldDefinition(field *CustomField) error {
+	if field.FieldName == "" || field.Label == "" {
+		return fmt.Errorf("custom field name and label are required")
+	}
+	switch field.FieldType {
+	case CustomFieldText, CustomFieldNumber, CustomFieldCheckbox, CustomFieldDatetime:
+	case CustomFieldPicklist:
+		if field.PicklistCategory == "" {
+			return fmt.Errorf("picklist custom field %q needs a picklist category", field.FieldName)
+		}
+	default:
+		return fmt.Errorf("invalid custom field type: %q", field.FieldType)
+	}
+	return nil
+}
+
+// validateCustomValue checks a stored custom field value against the field type
+func (db *DB) validateCustomValue(field CustomField, value string) error {
+	if value == "" {
+		return nil
+	}
+
+	var err error
+	switch field.FieldType {
+	case CustomFieldNumber:
+		_, err = strconv.ParseFloat(value, 64)
+	case CustomFieldCheckbox:
+		_, err = strconv.ParseBool(value)
+	case CustomFieldDatetime:
+		_, err = time.Parse(time.RFC3339, value)
+	case CustomFieldPicklist:
+		return db.validatePicklistValue(field.PicklistCategory, value)
+	}
+	if err != nil {
+		return fmt.Errorf("invalid value for %s: %q", field.Label, value)
+	}
+	return nil
+}
+
+// ValidateCallFields enforces the required fields configured in form_fields
+// and custom_fields, and checks custom field values against their types
+func (db *DB) ValidateCallFields(call *Call, apparatusIDs []int, responderIDs []int) error {
+	formFields, err := db.GetFormFields()
+	if err != nil {
+		return err
+	}
+
+	present := map[string]bool{
+		"incident_number": call.IncidentNumber != "",
+		"call_type":       call.CallType != "",
+		"mutual_aid":      call.MutualAid != "",
+		"address":         call.Address != "",
+		"town":            call.Town != "",
+		"location_notes":  call.LocationNotes != "",
+		"apparatus":       len(apparatusIDs) > 0,
+		"responders":      len(responderIDs) > 0,
+		"dispatched":      !call.Dispatched.IsZero(),
+		"enroute":         call.Enroute != nil,
+		"on_scene":        call.OnScene != nil,
+		"clear":           call.Clear != nil,
+		"narrative":       strings.TrimSpace(call.Narrative) != "",
+		"temperature_f":   call.TemperatureF != nil,
+		"wind":            call.Wind != "",
+		"precipitation":   call.Precipitation != "",
+		"road_conditions": call.RoadConditions != "",
+	}
+
+	var missing []string
+	for _, f := range formFields {
+		if !f.Enabled || !f.Required {
+			continue
+		}
+		if ok, known := present[f.FieldName]; known && !ok {
+			missing = append(missing, f.Label)
+		}
+	}
+
+	customFields, err := db.GetCustomFields()
+	if err != nil {
+		return err
+	}
+	defined := make(map[string]bool)
+	for _, f := range customFields {
+		defined[f.FieldName] = true
+		value := strings.TrimSpace(call.CustomFields[f.FieldName])
+		if value == "" {
+			if f.Required {
+				missing = append(missing, f.Label)
+			}
+			continue
+		}
+		if err := db.validateCustomValue(f, value); err != nil {
+			return err
+		}
+	}
+	for name := range call.CustomFields {
+		if !defined[name] {
+			return fmt.Errorf("unknown custom field: %s", name)
+		}
+	}
+
+	if len(missing) > 0 {
+		return fmt.Errorf("missing required fields: %s", strings.Join(missing, ", "))
+	}
+	return db.validateWeather(call)
+}
+
+// saveCustomValues replaces the active custom field values stored for a
+// call. Values of retired fields are left as they were.
+func saveCustomValues(tx *sql.Tx, callID int, values map[string]string) error {
+	_, err := tx.Exec(`
+		DELETE FROM call_custom_values
+		WHERE call_id = ? AND custom_field_id IN (SELECT id FROM custom_fields WHERE active = 1)
+	`, callID)
+	if err != nil {
+		return err
+	}
+
+	for name, value := range values {
+		if value == "" {
+			continue
+		}
+		_, err = tx.Exec(`
+			INSERT INTO call_custom_values (call_id, custom_field_id, value)
+			SELECT ?, id, ? FROM custom_fields WHERE field_name = ? AND active = 1
+		`, callID, value, name)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetCustomValuesForCalls returns active custom field values keyed by call
+// ID, then field name. Values of retired fields stay stored but are not
+// returned, so a loaded call can be saved back unchanged.
+func (db *DB) GetCustomValuesForCalls(callIDs []int) (map[int]map[string]string, error) {
+	values := make(map[int]map[string]string)
+	if len(callIDs) == 0 {
+		return values, nil
+	}
+
+	args := make([]interface{}, len(callIDs))
+	for i, id := range callIDs {
+		args[i] = id
+	}
+
+	rows, err := db.Query(`
+		SELECT v.call_id, f.field_name, v.value
+		FROM call_custom_values v
+		JOIN custom_fields f ON f.id = v.custom_field_id AND f.active = 1
+		WHERE v.call_id IN (`+placeholders(len(callIDs))+`)
+	`, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var callID int
+		var name, value string
+		if err := rows.Scan(&callID, &name, &value); err != nil {
+			return nil, err
+		}
+		if values[callID] == nil {
+			values[callID] = make(map[string]string)
+		}
+		values[callID][name] = value
+	}
+	return values, rows.Err()
+}
+
+// AttachCustomValues fills in the CustomFields of each call
+func (db *DB) AttachCustomValues(calls []Call) error {
+	ids := make([]int, len(calls))
+	for i, call := range calls {
+		ids[i] = call.ID
+	}
+
+	values, err := db.GetCustomValuesForCalls(ids)
+	if err != nil {
+		return err
+	}
+	for i := range calls {
+		calls[i].CustomFields = values[calls[i].ID]
+	}
+	return nil
+}
@@ -0,0 +1,202 @@
+package db
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRequiredFormFieldsEnforced(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	fields, err := db.GetFormFields()
+	if err != nil {
+		t.Fatalf("Failed to get form fields: %v", err)
+	}
+	for _, f := range fields {
+		if f.FieldName == "town" {
+			f.Required = true
+			if err := db.UpdateFormField(&f); err != nil {
+				t.Fatalf("Failed to update form field: %v", err)
+			}
+		}
+	}
+
+	call := &Call{
+		CallType:   "Rescue",
+		Address:    "9 River Rd",
+		Dispatched: time.Now(),
+		Narrative:  "Ice rescue",
+		CreatedBy:  1,
+	}
+	err = db.CreateCall(call, nil, nil, nil)
+	if err == nil || !strings.Contains(err.Error(), "Town") {
+		t.Fatalf("Expected missing Town error, got %v", err)
+	}
+
+	call.Town = "Stamford"
+	if err := db.CreateCall(call, nil, nil, nil); err != nil {
+		t.Fatalf("Failed to create call with town: %v", err)
+	}
+}
+
+func TestCustomFieldValues(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	field := &CustomField{FieldName: "water_used", Label: "Water Used (gal)", FieldType: CustomFieldNumber}
+	if err := db.CreateCustomField(field); err != nil {
+		t.Fatalf("Failed to create custom field: %v", err)
+	}
+
+	call := &Call{
+		CallType:     "Grass Fire",
+		Address:      "Old County Rd",
+		Dispatched:   time.Now(),
+		Narrative:    "Brush fire",
+		CreatedBy:    1,
+		CustomFields: map[string]string{"water_used": "lots"},
+	}
+	if err := db.CreateCall(call, nil, nil, nil); err == nil {
+		t.Fatal("Expected error for non-numeric value")
+	}
+
+	call.CustomFields["water_used"] = "750"
+	if err := db.CreateCall(call, nil, nil, nil); err != nil {
+		t.Fatalf("Failed to create call: %v", err)
+	}
+
+	saved, _, _, err := db.GetCallByID(call.ID)
+	if err != nil {
+		t.Fatalf("Failed to get call: %v", err)
+	}
+	if saved.CustomFields["water_used"] != "750" {
+		t.Errorf("Expected water_used 750, got %q", saved.CustomFields["water_used"])
+	}
+}
+
+func TestRetiredCustomFieldKeepsValues(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	field := &CustomField{FieldName: "water_used", Label: "Water Used (gal)", FieldType: CustomFieldNumber}
+	if err := db.CreateCustomField(field); err != nil {
+		t.Fatalf("Failed to create custom field: %v", err)
+	}
+	call := createTestCall(t, db, "Grass Fire", "Old County Rd", time.Now())
+	call.CustomFields = map[string]string{"water_used": "750"}
+	if err := db.UpdateCall(call, nil, nil, nil); err != nil {
+		t.Fatalf("Failed to set custom value: %v", err)
+	}
+
+	if err := db.DeleteCustomField(field.ID); err != nil {
+		t.Fatalf("Failed to retire custom field: %v", err)
+	}
+
+	saved, _, _, err := db.GetCallByID(call.ID)
+	if err != nil {
+		t.Fatalf("Failed to get call: %v", err)
+	}
+	if _, ok := saved.CustomFields["water_used"]; ok {
+		t.Errorf("Expected retired field to be left out, got %v", saved.CustomFields)
+	}
+	if err := db.UpdateCall(saved, nil, nil, nil); err != nil {
+		t.Fatalf("Expected call with a retired field value to save, got %v", err)
+	}
+
+	var stored string
+	err = db.QueryRow("SELECT value FROM call_custom_values WHERE call_id = ? AND custom_field_id = ?", call.ID, field.ID).Scan(&stored)
+	if err != nil || stored != "750" {
+		t.Errorf("Expected retired value 750 to be kept, got %q (%v)", stored, err)
+	}
+}
+
+func TestWizardCallPayload(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	// The object saveCall in frontend/src/app.js sends to CreateCall
+	payload := `{
+		"call_type": "Structure Fire",
+		"mutual_aid": "None",
+		"address": "12 Main St",
+		"town": "Stamford",
+		"location_notes": "Rear entrance",
+		"dispatched": "2026-03-01T12:00:00.000Z",
+		"enroute": "2026-03-01T12:03:00.000Z",
+		"on_scene": null,
+		"clear": null,
+		"narrative": "Kitchen fire",
+		"temperature_f": 28,
+		"wind": "Calm",
+		"precipitation": "",
+		"road_conditions": "Icy",
+		"created_by": 1
+	}`
+	var call Call
+	if err := json.Unmarshal([]byte(payload), &call); err != nil {
+		t.Fatalf("Failed to decode wizard payload: %v", err)
+	}
+	if err := db.CreateCall(&call, nil, nil, nil); err != nil {
+		t.Fatalf("Failed to create call from wizard payload: %v", err)
+	}
+
+	saved, _, _, err := db.GetCallByID(call.ID)
+	if err != nil {
+		t.Fatalf("Failed to get call: %v", err)
+	}
+	if saved.CallType != "Structure Fire" || saved.MutualAid != MutualAidNone || saved.LocationNotes != "Rear entrance" ||
+		saved.CreatedBy != 1 || saved.Enroute == nil || saved.TemperatureF == nil || *saved.TemperatureF != 28 ||
+		saved.Wind != "Calm" || saved.RoadConditions != "Icy" {
+		t.Errorf("Wizard payload did not round-trip: %+v", saved)
+	}
+
+	// Go field names do not match the json tags, so the type is lost
+	var goNames Call
+	if err := json.Unmarshal([]byte(`{"CallType": "Structure Fire"}`), &goNames); err != nil {
+		t.Fatalf("Failed to decode payload: %v", err)
+	}
+	if goNames.CallType != "" {
+		t.Errorf("Expected CallType key to be ignored, got %q", goNames.CallType)
+	}
+}
+
+func TestClearCallAssignmentsSkipsRequiredFields(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	units, err := db.GetAllApparatus()
+	if err != nil || len(units) == 0 {
+		t.Fatalf("Expected seeded apparatus, got %d (%v)", len(units), err)
+	}
+	call := &Call{CallType: "Rescue", Address: "9 River Rd", Town: "Stamford", Dispatched: time.Now(), Narrative: "Ice rescue", CreatedBy: 1}
+	if err := db.CreateCall(call, []int{units[0].ID}, []int{1}, nil); err != nil {
+		t.Fatalf("Failed to create call: %v", err)
+	}
+
+	fields, err := db.GetFormFields()
+	if err != nil {
+		t.Fatalf("Failed to get form fields: %v", err)
+	}
+	for _, f := range fields {
+		if f.FieldName == "apparatus" || f.FieldName == "responders" {
+			f.Required = true
+			if err := db.UpdateFormField(&f); err != nil {
+				t.Fatalf("Failed to update form field: %v", err)
+			}
+		}
+	}
+
+	if err := db.ClearCallAssignments(call.ID); err != nil {
+		t.Fatalf("Failed to clear call assignments: %v", err)
+	}
+	_, apparatus, responders, err := db.GetCallByID(call.ID)
+	if err != nil {
+		t.Fatalf("Failed to get call: %v", err)
+	}
+	if len(apparatus) != 0 || len(responders) != 0 {
+		t.Errorf("Expected no apparatus or responders, got %d and %d", len(apparatus), len(responders))
+	}
+}
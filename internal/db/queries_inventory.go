@@ -0,0 +1,277 @@
+package db
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ensureInventoryItems seeds the inventory category picklist and, for a
+// catalog that has never been set up, a starter set of items
+func (db *DB) ensureInventoryItems() error {
+	err := db.ensurePicklistCategory("inventory_category", []string{"SCBA", "Foam", "Absorbent", "EMS Supplies", "Other"})
+	if err != nil {
+		return err
+	}
+
+	var count int
+	if err := db.QueryRow("SELECT COUNT(*) FROM inventory_items").Scan(&count); err != nil {
+		return err
+	}
+	if count > 0 {
+		return nil
+	}
+
+	items := []InventoryItem{
+		{Name: "SCBA Cylinder Fill", Category: "SCBA", Unit: "fill"},
+		{Name: "Class A Foam", Category: "Foam", Unit: "gallon"},
+		{Name: "Class B Foam", Category: "Foam", Unit: "gallon"},
+		{Name: "Absorbent", Category: "Absorbent", Unit: "bag"},
+		{Name: "Oxygen Cylinder", Category: "EMS Supplies", Unit: "cylinder"},
+		{Name: "Trauma Dressing", Category: "EMS Supplies", Unit: "each"},
+	}
+	for i, item := range items {
+		_, err := db.Exec(`
+			INSERT OR IGNORE INTO inventory_items (name, category, unit, sort_order)
+			VALUES (?, ?, ?, ?)
+		`, item.Name, item.Category, item.Unit, i+1)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// inventoryItemColumns is the column list read by queryInventoryItems
+const inventoryItemColumns = `id, name, category, unit, unit_cost_cents, active, sort_order`
+
+// GetInventoryItems returns the active catalog items
+func (db *DB) GetInventoryItems() ([]InventoryItem, error) {
+	return db.queryInventoryItems(`
+		SELECT ` + inventoryItemColumns + `
+		FROM inventory_items
+		WHERE active = 1
+		ORDER BY sort_order, name
+	`)
+}
+
+// GetAllInventoryItems returns every catalog item, including inactive ones (for admin)
+func (db *DB) GetAllInventoryItems() ([]InventoryItem, error) {
+	return db.queryInventoryItems(`
+		SELECT ` + inventoryItemColumns + `
+		FROM inventory_items
+		ORDER BY sort_order, name
+	`)
+}
+
+// queryInventoryItems runs a catalog query and scans the results
+func (db *DB) queryInventoryItems(query string, args ...interface{}) ([]InventoryItem, error) {
+	rows, err := db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var items []InventoryItem
+	for rows.Next() {
+		var item InventoryItem
+		err := rows.Scan(&item.ID, &item.Name, &item.Category, &item.Unit,
+			&item.UnitCostCents, &item.Active, &item.SortOrder)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	return items, rows.Err()
+}
+
+// validateInventoryItem checks a catalog item before it is saved
+func (db *DB) validateInventoryItem(item *InventoryItem) error {
+	item.Name = strings.TrimSpace(item.Name)
+	if item.Name == "" {
+		return errors.New("item name is required")
+	}
+	if item.Unit == "" {
+		item.Unit = "each"
+	}
+	if item.UnitCostCents < 0 {
+		return errors.New("unit cost cannot be negative")
+	}
+	return db.validatePicklistValue("inventory_category", item.Category)
+}
+
+// CreateInventoryItem adds an item to the catalog
+func (db *DB) CreateInventoryItem(item *InventoryItem) error {
+	if err := db.validateInventoryItem(item); err != nil {
+		return err
+	}
+
+	item.Active = true
+	result, err := db.Exec(`
+		INSERT INTO inventory_items (name, category, unit, unit_cost_cents, active, sort_order)
+		VALUES (?, ?, ?, ?, 1, ?)
+	`, item.Name, item.Category, item.Unit, item.UnitCostCents, item.SortOrder)
+	if err != nil {
+		return err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+	item.ID = int(id)
+	return nil
+}
+
+// UpdateInventoryItem updates a catalog item
+func (db *DB) UpdateInventoryItem(item *InventoryItem) error {
+	if err := db.validateInventoryItem(item); err != nil {
+		return err
+	}
+
+	_, err := db.Exec(`
+		UPDATE inventory_items
+		SET name = ?, category = ?, unit = ?, unit_cost_cents = ?, active = ?, sort_order = ?
+		WHERE id = ?
+	`, item.Name, item.Category, item.Unit, item.UnitCostCents, item.Active, item.SortOrder, item.ID)
+	return err
+}
+
+// DeleteInventoryItem soft-deletes a catalog item (sets active = false) so
+// past usage keeps its item
+func (db *DB) DeleteInventoryItem(id int) error {
+	_, err := db.Exec("UPDATE inventory_items SET active = 0 WHERE id = ?", id)
+	return err
+}
+
+// AddCallInventoryUsage records a quantity of an item used on a call
+func (db *DB) AddCallInventoryUsage(usage *InventoryUsage) error {
+	if usage.Quantity <= 0 {
+		return errors.New("quantity must be greater than zero")
+	}
+
+	var active bool
+	err := db.QueryRow("SELECT active FROM inventory_items WHERE id = ?", usage.ItemID).Scan(&active)
+	if err != nil || !active {
+		return fmt.Errorf("inventory item %d not found", usage.ItemID)
+	}
+
+	result, err := db.Exec(`
+		INSERT INTO call_inventory_usage (call_id, item_id, quantity, notes)
+		VALUES (?, ?, ?, ?)
+	`, usage.CallID, usage.ItemID, usage.Quantity, usage.Notes)
+	if err != nil {
+		return err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+	usage.ID = int(id)
+	return nil
+}
+
+// UpdateCallInventoryUsage changes the quantity or notes of a usage line
+func (db *DB) UpdateCallInventoryUsage(usage *InventoryUsage) error {
+	if usage.Quantity <= 0 {
+		return errors.New("quantity must be greater than zero")
+	}
+
+	_, err := db.Exec(`
+		UPDATE call_inventory_usage SET quantity = ?, notes = ? WHERE id = ?
+	`, usage.Quantity, usage.Notes, usage.ID)
+	return err
+}
+
+// DeleteCallInventoryUsage removes a usage line from a call
+func (db *DB) DeleteCallInventoryUsage(id int) error {
+	_, err := db.Exec("DELETE FROM call_inventory_usage WHERE id = ?", id)
+	return err
+}
+
+// GetCallInventoryUsage returns the items used on a call
+func (db *DB) GetCallInventoryUsage(callID int) ([]InventoryUsage, error) {
+	usage, err := db.GetInventoryUsageForCalls([]int{callID})
+	if err != nil {
+		return nil, err
+	}
+	return usage[callID], nil
+}
+
+// GetInventoryUsageForCalls returns usage lines for several calls keyed by call ID
+func (db *DB) GetInventoryUsageForCalls(callIDs []int) (map[int][]InventoryUsage, error) {
+	usage := make(map[int][]InventoryUsage)
+	if len(callIDs) == 0 {
+		return usage, nil
+	}
+
+	args := make([]interface{}, len(callIDs))
+	for i, id := range callIDs {
+		args[i] = id
+	}
+
+	rows, err := db.Query(`
+		SELECT u.id, u.call_id, u.item_id, i.name, i.unit, u.quantity, u.notes
+		FROM call_inventory_usage u
+		JOIN inventory_items i ON u.item_id = i.id
+		WHERE u.call_id IN (`+placeholders(len(callIDs))+`)
+		ORDER BY u.call_id, i.sort_order, i.name, u.id
+	`, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var u InventoryUsage
+		if err := rows.Scan(&u.ID, &u.CallID, &u.ItemID, &u.ItemName, &u.Unit, &u.Quantity, &u.Notes); err != nil {
+			return nil, err
+		}
+		usage[u.CallID] = append(usage[u.CallID], u)
+	}
+	return usage, rows.Err()
+}
+
+// GetInventoryUsageReport totals item usage on calls dispatched between
+// start and end, for budget requests
+func (db *DB) GetInventoryUsageReport(start, end time.Time) ([]InventoryUsageTotal, error) {
+	rows, err := db.Query(`
+		SELECT i.id, i.name, i.category, i.unit,
+		       SUM(u.quantity), COUNT(DISTINCT u.call_id),
+		       CAST(ROUND(SUM(u.quantity) * i.unit_cost_cents) AS INTEGER)
+		FROM call_inventory_usage u
+		JOIN inventory_items i ON u.item_id = i.id
+		JOIN calls c ON u.call_id = c.id
+		WHERE c.dispatched >= ? AND c.dispatched <= ?
+		GROUP BY i.id
+		ORDER BY i.category, i.sort_order, i.name
+	`, start, end)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var totals []InventoryUsageTotal
+	for rows.Next() {
+		var t InventoryUsageTotal
+		err := rows.Scan(&t.ItemID, &t.ItemName, &t.Category, &t.Unit,
+			&t.Quantity, &t.Calls, &t.TotalCostCents)
+		if err != nil {
+			return nil, err
+		}
+		totals = append(totals, t)
+	}
+	return totals, rows.Err()
+}
+
+// Summary returns a one-line description of the usage for reports,
+// e.g. "Class A Foam: 5 gallon"
+func (u InventoryUsage) Summary() string {
+	summary := fmt.Sprintf("%s: %g %s", u.ItemName, u.Quantity, u.Unit)
+	if u.Notes != "" {
+		summary += " (" + u.Notes + ")"
+	}
+	return summary
+}
@@ -0,0 +1,54 @@
+package db
+
+import (
+	"testing"
+	"time"
+)
+
+func TestInventoryUsageReport(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	foam := &InventoryItem{Name: "Test Foam", Category: "Foam", Unit: "gallon", UnitCostCents: 2500}
+	if err := db.CreateInventoryItem(foam); err != nil {
+		t.Fatalf("Failed to create item: %v", err)
+	}
+
+	march := time.Date(2026, 3, 10, 14, 0, 0, 0, time.Local)
+	first := createTestCall(t, db, "Vehicle Fire", "Route 100", march)
+	second := createTestCall(t, db, "Structure Fire", "3 School St", march.AddDate(0, 0, 5))
+	outside := createTestCall(t, db, "Structure Fire", "9 School St", march.AddDate(0, 2, 0))
+
+	for _, u := range []InventoryUsage{
+		{CallID: first.ID, ItemID: foam.ID, Quantity: 5},
+		{CallID: second.ID, ItemID: foam.ID, Quantity: 2.5, Notes: "Overhaul"},
+		{CallID: outside.ID, ItemID: foam.ID, Quantity: 10},
+	} {
+		usage := u
+		if err := db.AddCallInventoryUsage(&usage); err != nil {
+			t.Fatalf("Failed to add usage: %v", err)
+		}
+	}
+	if err := db.AddCallInventoryUsage(&InventoryUsage{CallID: first.ID, ItemID: foam.ID, Quantity: 0}); err == nil {
+		t.Error("Expected error for zero quantity")
+	}
+
+	report, err := db.GetInventoryUsageReport(march.AddDate(0, 0, -9), march.AddDate(0, 0, 21))
+	if err != nil {
+		t.Fatalf("Failed to get report: %v", err)
+	}
+	if len(report) != 1 {
+		t.Fatalf("Expected 1 item in report, got %d", len(report))
+	}
+	if report[0].Quantity != 7.5 || report[0].Calls != 2 || report[0].TotalCostCents != 18750 {
+		t.Errorf("Unexpected totals: %+v", report[0])
+	}
+
+	usage, err := db.GetCallInventoryUsage(second.ID)
+	if err != nil {
+		t.Fatalf("Failed to get call usage: %v", err)
+	}
+	if len(usage) != 1 || usage[0].Summary() != "Test Foam: 2.5 gallon (Overhaul)" {
+		t.Errorf("Unexpected call usage: %+v", usage)
+	}
+}
@@ -0,0 +1,147 @@
+package db
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Call link types. Rekindle and follow-up links point from the later call
+// to the original one; the other types are symmetric.
+const (
+	LinkRekindleOf           = "rekindle-of"
+	LinkFollowUpTo           = "follow-up-to"
+	LinkSameIncidentAs       = "same-incident-as"
+	LinkMutualAidCounterpart = "mutual-aid-counterpart"
+)
+
+// linkInverses gives the link type as seen from the other call
+var linkInverses = map[string]string{
+	LinkRekindleOf:           "rekindled-by",
+	LinkFollowUpTo:           "followed-up-by",
+	LinkSameIncidentAs:       LinkSameIncidentAs,
+	LinkMutualAidCounterpart: LinkMutualAidCounterpart,
+}
+
+// CallLinkTypes returns the link types that can be stored
+func CallLinkTypes() []string {
+	return []string{LinkRekindleOf, LinkFollowUpTo, LinkSameIncidentAs, LinkMutualAidCounterpart}
+}
+
+// CreateCallLink links callID to linkedCallID, e.g. callID is a rekindle-of linkedCallID
+func (db *DB) CreateCallLink(callID, linkedCallID int, linkType, notes string, createdBy int) (*CallLink, error) {
+	if _, ok := linkInverses[linkType]; !ok {
+		return nil, fmt.Errorf("invalid link type: %q", linkType)
+	}
+	if callID == linkedCallID {
+		return nil, errors.New("a call cannot be linked to itself")
+	}
+
+	var exists bool
+	err := db.QueryRow(`
+		SELECT EXISTS(
+			SELECT 1 FROM call_links
+			WHERE link_type = ? AND ((call_id = ? AND linked_call_id = ?) OR (call_id = ? AND linked_call_id = ?))
+		)
+	`, linkType, callID, linkedCallID, linkedCallID, callID).Scan(&exists)
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		return nil, errors.New("these calls are already linked")
+	}
+
+	result, err := db.Exec(`
+		INSERT INTO call_links (call_id, linked_call_id, link_type, notes, created_by)
+		VALUES (?, ?, ?, ?, ?)
+	`, callID, linkedCallID, linkType, notes, createdBy)
+	if err != nil {
+		return nil, fmt.Errorf("failed to link calls: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return nil, err
+	}
+	return &CallLink{ID: int(id), CallID: callID, LinkedCallID: linkedCallID, LinkType: linkType, Notes: notes}, nil
+}
+
+// DeleteCallLink removes a link between two calls
+func (db *DB) DeleteCallLink(id int) error {
+	_, err := db.Exec("DELETE FROM call_links WHERE id = ?", id)
+	return err
+}
+
+// GetCallLinks returns the links for a call in both directions, with the
+// link type expressed from that call's point of view
+func (db *DB) GetCallLinks(callID int) ([]CallLink, error) {
+	links, err := db.GetLinksForCalls([]int{callID})
+	if err != nil {
+		return nil, err
+	}
+	return links[callID], nil
+}
+
+// GetLinksForCalls returns links for several calls keyed by call ID
+func (db *DB) GetLinksForCalls(callIDs []int) (map[int][]CallLink, error) {
+	links := make(map[int][]CallLink)
+	if len(callIDs) == 0 {
+		return links, nil
+	}
+
+	args := make([]interface{}, 0, len(callIDs)*2)
+	for _, id := range callIDs {
+		args = append(args, id)
+	}
+	args = append(args, args...)
+
+	rows, err := db.Query(`
+		SELECT l.id, l.call_id, l.linked_call_id, l.link_type, l.notes, 0,
+		       c.incident_number, c.call_type, c.dispatched
+		FROM call_links l
+		JOIN calls c ON c.id = l.linked_call_id
+		WHERE l.call_id IN (`+placeholders(len(callIDs))+`)
+		UNION ALL
+		SELECT l.id, l.linked_call_id, l.call_id, l.link_type, l.notes, 1,
+		       c.incident_number, c.call_type, c.dispatched
+		FROM call_links l
+		JOIN calls c ON c.id = l.call_id
+		WHERE l.linked_call_id IN (`+placeholders(len(callIDs))+`)
+		ORDER BY 9
+	`, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var link CallLink
+		var inverse bool
+		err := rows.Scan(&link.ID, &link.CallID, &link.LinkedCallID, &link.LinkType, &link.Notes, &inverse,
+			&link.LinkedIncidentNumber, &link.LinkedCallType, &link.LinkedDispatched)
+		if err != nil {
+			return nil, err
+		}
+		if inverse {
+			link.LinkType = linkInverses[link.LinkType]
+		}
+		links[link.CallID] = append(links[link.CallID], link)
+	}
+	return links, rows.Err()
+}
+
+// AttachCallLinks fills in the Links field of each call
+func (db *DB) AttachCallLinks(calls []Call) error {
+	ids := make([]int, len(calls))
+	for i, call := range calls {
+		ids[i] = call.ID
+	}
+
+	links, err := db.GetLinksForCalls(ids)
+	if err != nil {
+		return err
+	}
+	for i := range calls {
+		calls[i].Links = links[calls[i].ID]
+	}
+	return nil
+}
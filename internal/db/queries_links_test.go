@@ -0,0 +1,117 @@
+package db
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCreateCallLinkRejectsSelfAndDuplicates(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	original := createTestCall(t, db, "Structure Fire", "12 Main St", time.Now().Add(-24*time.Hour))
+	rekindle := createTestCall(t, db, "Structure Fire", "12 Main St", time.Now())
+
+	if _, err := db.CreateCallLink(rekindle.ID, rekindle.ID, LinkRekindleOf, "", 1); err == nil {
+		t.Error("Expected error linking a call to itself")
+	}
+	if _, err := db.CreateCallLink(rekindle.ID, original.ID, "caused-by", "", 1); err == nil {
+		t.Error("Expected error for an unknown link type")
+	}
+
+	link, err := db.CreateCallLink(rekindle.ID, original.ID, LinkRekindleOf, "Hot spot in wall", 1)
+	if err != nil {
+		t.Fatalf("Failed to link calls: %v", err)
+	}
+	if link.ID == 0 || link.LinkType != LinkRekindleOf {
+		t.Errorf("Unexpected link %+v", link)
+	}
+
+	if _, err := db.CreateCallLink(rekindle.ID, original.ID, LinkRekindleOf, "", 1); err == nil {
+		t.Error("Expected error for a duplicate link")
+	}
+	if _, err := db.CreateCallLink(original.ID, rekindle.ID, LinkRekindleOf, "", 1); err == nil {
+		t.Error("Expected error for the same link in the other direction")
+	}
+	if _, err := db.CreateCallLink(rekindle.ID, original.ID, LinkSameIncidentAs, "", 1); err != nil {
+		t.Errorf("Expected a link of another type to be allowed, got %v", err)
+	}
+}
+
+func TestGetLinksForCallsInverseTypes(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	base := time.Date(2026, 5, 1, 12, 0, 0, 0, time.Local)
+	original := createTestCall(t, db, "Structure Fire", "12 Main St", base)
+	rekindle := createTestCall(t, db, "Structure Fire", "12 Main St", base.Add(6*time.Hour))
+	followUp := createTestCall(t, db, "Alarm Investigation", "12 Main St", base.AddDate(0, 0, 2))
+	aid := createTestCall(t, db, "Mutual Aid", "4 River Rd", base)
+
+	for _, l := range []struct {
+		from, to int
+		linkType string
+	}{
+		{rekindle.ID, original.ID, LinkRekindleOf},
+		{followUp.ID, original.ID, LinkFollowUpTo},
+		{aid.ID, original.ID, LinkMutualAidCounterpart},
+	} {
+		if _, err := db.CreateCallLink(l.from, l.to, l.linkType, "", 1); err != nil {
+			t.Fatalf("Failed to link calls: %v", err)
+		}
+	}
+
+	links, err := db.GetLinksForCalls([]int{original.ID, rekindle.ID})
+	if err != nil {
+		t.Fatalf("Failed to get links: %v", err)
+	}
+
+	types := make(map[int]string)
+	for _, link := range links[original.ID] {
+		if link.CallID != original.ID {
+			t.Errorf("Expected links from the original call's point of view, got %+v", link)
+		}
+		types[link.LinkedCallID] = link.LinkType
+	}
+	want := map[int]string{rekindle.ID: "rekindled-by", followUp.ID: "followed-up-by", aid.ID: LinkMutualAidCounterpart}
+	for id, linkType := range want {
+		if types[id] != linkType {
+			t.Errorf("Expected call %d to be %q from the original, got %q", id, linkType, types[id])
+		}
+	}
+
+	if got := links[rekindle.ID]; len(got) != 1 || got[0].LinkType != LinkRekindleOf ||
+		got[0].LinkedCallID != original.ID || got[0].LinkedIncidentNumber != original.IncidentNumber {
+		t.Errorf("Expected the rekindle to link to the original, got %+v", got)
+	}
+}
+
+func TestAttachCallLinks(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	original := createTestCall(t, db, "Structure Fire", "12 Main St", time.Now().Add(-time.Hour))
+	rekindle := createTestCall(t, db, "Structure Fire", "12 Main St", time.Now())
+	unlinked := createTestCall(t, db, "Rescue", "9 River Rd", time.Now())
+	if _, err := db.CreateCallLink(rekindle.ID, original.ID, LinkRekindleOf, "", 1); err != nil {
+		t.Fatalf("Failed to link calls: %v", err)
+	}
+
+	calls := []Call{*original, *rekindle, *unlinked}
+	if err := db.AttachCallLinks(calls); err != nil {
+		t.Fatalf("Failed to attach links: %v", err)
+	}
+	if len(calls[0].Links) != 1 || calls[0].Links[0].LinkType != "rekindled-by" {
+		t.Errorf("Expected the original to be rekindled-by, got %+v", calls[0].Links)
+	}
+	if len(calls[1].Links) != 1 || calls[1].Links[0].LinkType != LinkRekindleOf {
+		t.Errorf("Expected the rekindle to be rekindle-of, got %+v", calls[1].Links)
+	}
+	if calls[2].Links != nil {
+		t.Errorf("Expected no links on the unlinked call, got %+v", calls[2].Links)
+	}
+
+	if err := db.AttachCallLinks(nil); err != nil {
+		t.Errorf("Expected no error attaching links to no calls, got %v", err)
+	}
+}
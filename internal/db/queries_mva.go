@@ -0,0 +1,338 @@
+package db
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// mvaCallType is the seeded call type for motor vehicle accidents
+const mvaCallType = "Motor Vehicle Accident"
+
+// mvaIncidentCodes are the NFIRS incident types for motor vehicle accidents
+var mvaIncidentCodes = []string{"322", "323", "324"}
+
+// ensureMVAPicklists seeds the picklists used by MVA vehicle records
+func (db *DB) ensureMVAPicklists() error {
+	categories := []struct {
+		category string
+		values   []string
+	}{
+		{"vehicle_damage", []string{"None", "Minor", "Moderate", "Severe", "Disabling"}},
+		{"airbag_deployment", []string{"Deployed - Front", "Deployed - Side", "Deployed - Multiple", "Not Deployed", "Not Equipped", "Unknown"}},
+	}
+
+	for _, c := range categories {
+		if err := db.ensurePicklistCategory(c.category, c.values); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateVehicle checks a vehicle's picklist fields and that its linked
+// parties belong to the same call
+func (db *DB) validateVehicle(vehicle *MVAVehicle) error {
+	if vehicle.Year != 0 && (vehicle.Year < 1900 || vehicle.Year > time.Now().Year()+1) {
+		return fmt.Errorf("invalid vehicle year: %d", vehicle.Year)
+	}
+
+	fields := []struct {
+		category string
+		value    string
+	}{
+		{"vehicle_damage", vehicle.Damage},
+		{"airbag_deployment", vehicle.AirbagDeployment},
+	}
+	for _, f := range fields {
+		if err := db.validatePicklistValue(f.category, f.value); err != nil {
+			return err
+		}
+	}
+
+	for _, partyID := range vehicle.PartyIDs {
+		var callID int
+		err := db.QueryRow("SELECT call_id FROM call_parties WHERE id = ?", partyID).Scan(&callID)
+		if err == sql.ErrNoRows || (err == nil && callID != vehicle.CallID) {
+			return fmt.Errorf("party %d is not involved in this call", partyID)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// CreateMVAVehicle adds a vehicle to an MVA call and links its parties
+func (db *DB) CreateMVAVehicle(vehicle *MVAVehicle) error {
+	// Same rule as GetMVAsByLocation, less the vehicle records being added
+	args := []interface{}{mvaCallType}
+	for _, code := range mvaIncidentCodes {
+		args = append(args, code)
+	}
+	args = append(args, vehicle.CallID)
+
+	var isMVA bool
+	err := db.QueryRow(`
+		SELECT call_type = ? OR incident_type_code IN (`+placeholders(len(mvaIncidentCodes))+`)
+		FROM calls WHERE id = ?
+	`, args...).Scan(&isMVA)
+	if err == sql.ErrNoRows {
+		return errors.New("call not found")
+	}
+	if err != nil {
+		return err
+	}
+	if !isMVA {
+		return fmt.Errorf("vehicles can only be added to %s calls", mvaCallType)
+	}
+
+	if err := db.validateVehicle(vehicle); err != nil {
+		return err
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	result, err := tx.Exec(`
+		INSERT INTO mva_vehicles (
+			call_id, vehicle_year, make, model, plate, plate_state,
+			damage, extrication, airbag_deployment, notes
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`, vehicle.CallID, vehicle.Year, vehicle.Make, vehicle.Model, vehicle.Plate, vehicle.PlateState,
+		vehicle.Damage, vehicle.Extrication, vehicle.AirbagDeployment, vehicle.Notes)
+	if err != nil {
+		return err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+	vehicle.ID = int(id)
+
+	if err := saveVehicleParties(tx, vehicle.ID, vehicle.PartyIDs); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// UpdateMVAVehicle updates a vehicle and replaces its linked parties. A
+// vehicle stays on the call it was added to.
+func (db *DB) UpdateMVAVehicle(vehicle *MVAVehicle) error {
+	err := db.QueryRow("SELECT call_id FROM mva_vehicles WHERE id = ?", vehicle.ID).Scan(&vehicle.CallID)
+	if err == sql.ErrNoRows {
+		return errors.New("vehicle not found")
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := db.validateVehicle(vehicle); err != nil {
+		return err
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.Exec(`
+		UPDATE mva_vehicles SET
+			vehicle_year = ?, make = ?, model = ?, plate = ?, plate_state = ?,
+			damage = ?, extrication = ?, airbag_deployment = ?, notes = ?
+		WHERE id = ?
+	`, vehicle.Year, vehicle.Make, vehicle.Model, vehicle.Plate, vehicle.PlateState,
+		vehicle.Damage, vehicle.Extrication, vehicle.AirbagDeployment, vehicle.Notes, vehicle.ID)
+	if err != nil {
+		return err
+	}
+
+	if err := saveVehicleParties(tx, vehicle.ID, vehicle.PartyIDs); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// saveVehicleParties replaces the parties linked to a vehicle
+func saveVehicleParties(tx *sql.Tx, vehicleID int, partyIDs []int) error {
+	if _, err := tx.Exec("DELETE FROM mva_vehicle_parties WHERE vehicle_id = ?", vehicleID); err != nil {
+		return err
+	}
+	for _, partyID := range partyIDs {
+		_, err := tx.Exec(`
+			INSERT OR IGNORE INTO mva_vehicle_parties (vehicle_id, party_id) VALUES (?, ?)
+		`, vehicleID, partyID)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DeleteMVAVehicle removes a vehicle from a call
+func (db *DB) DeleteMVAVehicle(id int) error {
+	_, err := db.Exec("DELETE FROM mva_vehicles WHERE id = ?", id)
+	return err
+}
+
+// GetMVAVehicles returns the vehicles recorded on a call
+func (db *DB) GetMVAVehicles(callID int) ([]MVAVehicle, error) {
+	vehicles, err := db.GetMVAVehiclesForCalls([]int{callID})
+	if err != nil {
+		return nil, err
+	}
+	return vehicles[callID], nil
+}
+
+// GetMVAVehiclesForCalls returns vehicles with their linked party IDs for
+// several calls keyed by call ID
+func (db *DB) GetMVAVehiclesForCalls(callIDs []int) (map[int][]MVAVehicle, error) {
+	vehicles := make(map[int][]MVAVehicle)
+	if len(callIDs) == 0 {
+		return vehicles, nil
+	}
+
+	args := make([]interface{}, len(callIDs))
+	for i, id := range callIDs {
+		args[i] = id
+	}
+
+	rows, err := db.Query(`
+		SELECT v.id, v.call_id, v.vehicle_year, v.make, v.model, v.plate, v.plate_state,
+		       v.damage, v.extrication, v.airbag_deployment, v.notes,
+		       COALESCE((SELECT GROUP_CONCAT(party_id) FROM mva_vehicle_parties WHERE vehicle_id = v.id), '')
+		FROM mva_vehicles v
+		WHERE v.call_id IN (`+placeholders(len(callIDs))+`)
+		ORDER BY v.call_id, v.id
+	`, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var v MVAVehicle
+		var partyIDs string
+		err := rows.Scan(&v.ID, &v.CallID, &v.Year, &v.Make, &v.Model, &v.Plate, &v.PlateState,
+			&v.Damage, &v.Extrication, &v.AirbagDeployment, &v.Notes, &partyIDs)
+		if err != nil {
+			return nil, err
+		}
+		for _, id := range strings.Split(partyIDs, ",") {
+			if n, err := strconv.Atoi(id); err == nil {
+				v.PartyIDs = append(v.PartyIDs, n)
+			}
+		}
+		vehicles[v.CallID] = append(vehicles[v.CallID], v)
+	}
+	return vehicles, rows.Err()
+}
+
+// GetMVAsByLocation returns MVA calls on a road, optionally limited to a
+// town, a block range of house numbers and a dispatch period. The road is
+// normalized like premise addresses, so "Route 8" matches "1200 Rte 8" and
+// "rt 8". A zero fromNumber/toNumber or start/end leaves that bound open.
+// Calls count as MVAs by call type, NFIRS code, or having vehicle records.
+func (db *DB) GetMVAsByLocation(road, town string, fromNumber, toNumber int, start, end time.Time) (*MVALocationReport, error) {
+	key := NormalizeAddress(road)
+	if key == "" {
+		return nil, errors.New("road or address is required")
+	}
+	report := &MVALocationReport{Road: key, Town: town}
+
+	query := `
+		SELECT ` + callColumns + `
+		FROM calls
+		WHERE (address_key = ? OR address_key LIKE ? OR address_key LIKE ? OR address_key LIKE ?)
+		AND (call_type = ? OR incident_type_code IN (` + placeholders(len(mvaIncidentCodes)) + `)
+		     OR id IN (SELECT call_id FROM mva_vehicles))
+	`
+	args := []interface{}{key, "% " + key, key + " %", "% " + key + " %", mvaCallType}
+	for _, code := range mvaIncidentCodes {
+		args = append(args, code)
+	}
+	if town != "" {
+		query += " AND town = ? COLLATE NOCASE"
+		args = append(args, town)
+	}
+	if !start.IsZero() {
+		query += " AND dispatched >= ?"
+		args = append(args, start)
+	}
+	if !end.IsZero() {
+		query += " AND dispatched <= ?"
+		args = append(args, end)
+	}
+	query += " ORDER BY dispatched DESC"
+
+	rows, err := db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var calls []Call
+	for rows.Next() {
+		var call Call
+		if err := scanCall(rows, &call); err != nil {
+			return nil, err
+		}
+		if !inBlockRange(call.AddressKey, fromNumber, toNumber) {
+			continue
+		}
+		calls = append(calls, call)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	callIDs := make([]int, len(calls))
+	for i, call := range calls {
+		callIDs[i] = call.ID
+	}
+	vehicles, err := db.GetMVAVehiclesForCalls(callIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, call := range calls {
+		incident := MVAIncident{Call: call, Vehicles: vehicles[call.ID]}
+		for _, v := range incident.Vehicles {
+			report.Vehicles++
+			if v.Extrication {
+				report.Extrications++
+			}
+			if strings.HasPrefix(v.AirbagDeployment, "Deployed") {
+				report.AirbagDeployments++
+			}
+		}
+		report.Incidents = append(report.Incidents, incident)
+	}
+	return report, nil
+}
+
+// inBlockRange reports whether an address key's leading house number falls
+// within [from, to]. Open ranges always match; addresses without a house
+// number only match open ranges.
+func inBlockRange(addressKey string, from, to int) bool {
+	if from == 0 && to == 0 {
+		return true
+	}
+	fields := strings.Fields(addressKey)
+	if len(fields) == 0 {
+		return false
+	}
+	number, err := strconv.Atoi(fields[0])
+	if err != nil {
+		return false
+	}
+	return (from == 0 || number >= from) && (to == 0 || number <= to)
+}
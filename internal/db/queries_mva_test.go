@@ -0,0 +1,109 @@
+package db
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMVAsByLocation(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	now := time.Now()
+	near := createTestCall(t, db, "Motor Vehicle Accident", "1200 Route 8", now)
+	far := createTestCall(t, db, "Motor Vehicle Accident", "4100 Rte. 8", now)
+	createTestCall(t, db, "Motor Vehicle Accident", "12 Route 80", now)
+	createTestCall(t, db, "Structure Fire", "1210 Route 8", now)
+
+	driver := &Party{CallID: near.ID, Role: "Driver", Name: "Sam Lee"}
+	if err := db.CreateParty(driver); err != nil {
+		t.Fatalf("Failed to create party: %v", err)
+	}
+	vehicle := &MVAVehicle{CallID: near.ID, Year: 2019, Make: "Ford", Model: "F-150", Plate: "XYZ789",
+		PlateState: "VT", Damage: "Severe", Extrication: true, AirbagDeployment: "Deployed - Front",
+		PartyIDs: []int{driver.ID}}
+	if err := db.CreateMVAVehicle(vehicle); err != nil {
+		t.Fatalf("Failed to create vehicle: %v", err)
+	}
+
+	other := &Party{CallID: far.ID, Role: "Driver"}
+	if err := db.CreateParty(other); err != nil {
+		t.Fatalf("Failed to create party: %v", err)
+	}
+	if err := db.CreateMVAVehicle(&MVAVehicle{CallID: near.ID, PartyIDs: []int{other.ID}}); err == nil {
+		t.Error("Expected error linking a party from another call")
+	}
+
+	report, err := db.GetMVAsByLocation("route 8", "", 0, 0, time.Time{}, time.Time{})
+	if err != nil {
+		t.Fatalf("Failed to get MVAs: %v", err)
+	}
+	if len(report.Incidents) != 2 {
+		t.Fatalf("Expected 2 MVAs on rt 8, got %d", len(report.Incidents))
+	}
+	if report.Vehicles != 1 || report.Extrications != 1 || report.AirbagDeployments != 1 {
+		t.Errorf("Unexpected totals: %+v", report)
+	}
+
+	report, err = db.GetMVAsByLocation("Route 8", "stamford", 1000, 2000, time.Time{}, time.Time{})
+	if err != nil {
+		t.Fatalf("Failed to get MVAs: %v", err)
+	}
+	if len(report.Incidents) != 1 || report.Incidents[0].Call.ID != near.ID {
+		t.Fatalf("Expected only the 1200 block MVA, got %d", len(report.Incidents))
+	}
+	v := report.Incidents[0].Vehicles[0]
+	if len(v.PartyIDs) != 1 || v.PartyIDs[0] != driver.ID {
+		t.Errorf("Expected vehicle linked to driver, got %v", v.PartyIDs)
+	}
+
+	RedactPII(report)
+	if report.Incidents[0].Vehicles[0].Plate != "" || report.Incidents[0].Vehicles[0].Make != "Ford" {
+		t.Errorf("Expected only the plate to be redacted: %+v", report.Incidents[0].Vehicles[0])
+	}
+}
+
+func TestMVAVehicleCallChecks(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	now := time.Now()
+	mva := createTestCall(t, db, "Motor Vehicle Accident", "1200 Route 8", now)
+	other := createTestCall(t, db, "Motor Vehicle Accident", "4100 Route 8", now)
+	fire := createTestCall(t, db, "Structure Fire", "1210 Route 8", now)
+
+	if err := db.CreateMVAVehicle(&MVAVehicle{CallID: fire.ID, Make: "Ford"}); err == nil {
+		t.Error("Expected error adding a vehicle to a structure fire")
+	}
+	if err := db.CreateMVAVehicle(&MVAVehicle{CallID: 9999, Make: "Ford"}); err == nil {
+		t.Error("Expected error adding a vehicle to a missing call")
+	}
+
+	driver := &Party{CallID: mva.ID, Role: "Driver"}
+	stranger := &Party{CallID: other.ID, Role: "Driver"}
+	for _, p := range []*Party{driver, stranger} {
+		if err := db.CreateParty(p); err != nil {
+			t.Fatalf("Failed to create party: %v", err)
+		}
+	}
+	vehicle := &MVAVehicle{CallID: mva.ID, Make: "Ford", PartyIDs: []int{driver.ID}}
+	if err := db.CreateMVAVehicle(vehicle); err != nil {
+		t.Fatalf("Failed to create vehicle: %v", err)
+	}
+
+	// Parties are checked against the stored call, not the one sent back
+	if err := db.UpdateMVAVehicle(&MVAVehicle{ID: vehicle.ID, CallID: other.ID, Make: "Ford", PartyIDs: []int{stranger.ID}}); err == nil {
+		t.Error("Expected error linking a party from another call")
+	}
+	if err := db.UpdateMVAVehicle(&MVAVehicle{ID: vehicle.ID, CallID: other.ID, Make: "Toyota", PartyIDs: []int{driver.ID}}); err != nil {
+		t.Fatalf("Failed to update vehicle: %v", err)
+	}
+	vehicles, err := db.GetMVAVehicles(mva.ID)
+	if err != nil || len(vehicles) != 1 || vehicles[0].Make != "Toyota" {
+		t.Errorf("Expected the updated vehicle to stay on its call, got %+v (%v)", vehicles, err)
+	}
+
+	if err := db.UpdateMVAVehicle(&MVAVehicle{ID: 9999, CallID: mva.ID}); err == nil {
+		t.Error("Expected error updating a missing vehicle")
+	}
+}
@@ -0,0 +1,121 @@
+package db
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// validateParty checks a party's role against the "party_role" picklist
+func (db *DB) validateParty(party *Party) error {
+	if party.Role == "" {
+		return errors.New("party role is required")
+	}
+	return db.validatePicklistValue("party_role", party.Role)
+}
+
+// CreateParty adds a person involved in a call
+func (db *DB) CreateParty(party *Party) error {
+	if err := db.validateParty(party); err != nil {
+		return err
+	}
+
+	result, err := db.Exec(`
+		INSERT INTO call_parties (
+			call_id, role, name, phone, address, vehicle_plate, vehicle_make, notes
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+	`, party.CallID, party.Role, party.Name, party.Phone, party.Address,
+		party.VehiclePlate, party.VehicleMake, party.Notes)
+	if err != nil {
+		return fmt.Errorf("failed to create party: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+	party.ID = int(id)
+	return nil
+}
+
+// UpdateParty updates a person involved in a call
+func (db *DB) UpdateParty(party *Party) error {
+	if err := db.validateParty(party); err != nil {
+		return err
+	}
+
+	_, err := db.Exec(`
+		UPDATE call_parties SET
+			role = ?, name = ?, phone = ?, address = ?,
+			vehicle_plate = ?, vehicle_make = ?, notes = ?
+		WHERE id = ?
+	`, party.Role, party.Name, party.Phone, party.Address,
+		party.VehiclePlate, party.VehicleMake, party.Notes, party.ID)
+	return err
+}
+
+// DeleteParty removes a person from a call
+func (db *DB) DeleteParty(id int) error {
+	_, err := db.Exec("DELETE FROM call_parties WHERE id = ?", id)
+	return err
+}
+
+// GetPartiesByCallID returns the people involved in a call
+func (db *DB) GetPartiesByCallID(callID int) ([]Party, error) {
+	parties, err := db.GetPartiesForCalls([]int{callID})
+	if err != nil {
+		return nil, err
+	}
+	return parties[callID], nil
+}
+
+// GetPartiesForCalls returns involved parties for several calls keyed by call ID
+func (db *DB) GetPartiesForCalls(callIDs []int) (map[int][]Party, error) {
+	parties := make(map[int][]Party)
+	if len(callIDs) == 0 {
+		return parties, nil
+	}
+
+	args := make([]interface{}, len(callIDs))
+	for i, id := range callIDs {
+		args[i] = id
+	}
+
+	rows, err := db.Query(`
+		SELECT id, call_id, role, name, phone, address, vehicle_plate, vehicle_make, notes, created_at
+		FROM call_parties
+		WHERE call_id IN (`+placeholders(len(callIDs))+`)
+		ORDER BY call_id, id
+	`, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var p Party
+		err := rows.Scan(&p.ID, &p.CallID, &p.Role, &p.Name, &p.Phone, &p.Address,
+			&p.VehiclePlate, &p.VehicleMake, &p.Notes, &p.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		parties[p.CallID] = append(parties[p.CallID], p)
+	}
+	return parties, rows.Err()
+}
+
+// Summary returns a one-line description of the party for reports. Blank
+// fields, including redacted PII, are left out.
+func (p Party) Summary() string {
+	parts := []string{p.Role}
+	for _, s := range []string{p.Name, p.Phone, p.Address} {
+		if s != "" {
+			parts = append(parts, s)
+		}
+	}
+	vehicle := strings.TrimSpace(p.VehicleMake + " " + p.VehiclePlate)
+	if vehicle != "" {
+		parts = append(parts, "vehicle "+vehicle)
+	}
+	return strings.Join(parts, ", ")
+}
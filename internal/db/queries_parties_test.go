@@ -0,0 +1,48 @@
+package db
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestPartiesAndPIIRedaction(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	call := createTestCall(t, db, "Motor Vehicle Accident", "Route 8", time.Now())
+
+	driver := &Party{CallID: call.ID, Role: "Driver", Name: "Jane Doe", Phone: "802-555-0100",
+		Address: "12 Oak St", VehiclePlate: "ABC123", VehicleMake: "Subaru"}
+	if err := db.CreateParty(driver); err != nil {
+		t.Fatalf("Failed to create party: %v", err)
+	}
+	if err := db.CreateParty(&Party{CallID: call.ID, Role: "Bystander"}); err == nil {
+		t.Error("Expected error for role not in picklist")
+	}
+
+	parties, err := db.GetPartiesByCallID(call.ID)
+	if err != nil {
+		t.Fatalf("Failed to get parties: %v", err)
+	}
+	if len(parties) != 1 || parties[0].Name != "Jane Doe" {
+		t.Fatalf("Unexpected parties: %+v", parties)
+	}
+
+	RedactPII(parties)
+	p := parties[0]
+	if p.Name != "" || p.Phone != "" || p.Address != "" || p.VehiclePlate != "" {
+		t.Errorf("PII not redacted: %+v", p)
+	}
+	if p.Role != "Driver" || p.VehicleMake != "Subaru" {
+		t.Errorf("Non-PII fields should be kept: %+v", p)
+	}
+	if p.Summary() != "Driver, vehicle Subaru" {
+		t.Errorf("Unexpected redacted summary: %q", p.Summary())
+	}
+
+	want := []string{"name", "phone", "address", "vehicle_plate", "notes"}
+	if got := PIIFields(Party{}); !reflect.DeepEqual(got, want) {
+		t.Errorf("PIIFields = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,184 @@
+package db
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ensurePatientPicklists seeds the picklists used by patient records
+func (db *DB) ensurePatientPicklists() error {
+	categories := []struct {
+		category string
+		values   []string
+	}{
+		{"patient_age_range", []string{"Infant (under 1)", "Child (1-12)", "Adolescent (13-17)", "Adult (18-64)", "Senior (65+)", "Unknown"}},
+		{"care_level", []string{"None", "First Aid", "BLS", "ALS"}},
+		{"transport_disposition", []string{"Transported by Own Agency", "Transported by Other Agency", "Treated, Not Transported", "Refused Care", "No Patient Found", "Dead at Scene"}},
+		{"hospital", []string{"Southwestern Vermont Medical Center", "Brattleboro Memorial Hospital", "Berkshire Medical Center", "Other"}},
+	}
+
+	for _, c := range categories {
+		if err := db.ensurePicklistCategory(c.category, c.values); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validatePatient checks picklist-backed patient fields against active picklist values
+func (db *DB) validatePatient(patient *Patient) error {
+	if patient.TreatingResponderID != nil {
+		var responded bool
+		err := db.QueryRow(`
+			SELECT EXISTS(SELECT 1 FROM call_responders WHERE call_id = ? AND responder_id = ?)
+		`, patient.CallID, *patient.TreatingResponderID).Scan(&responded)
+		if err != nil {
+			return err
+		}
+		if !responded {
+			return fmt.Errorf("responder %d did not respond to this call", *patient.TreatingResponderID)
+		}
+	}
+
+	fields := []struct {
+		category string
+		value    string
+	}{
+		{"patient_age_range", patient.AgeRange},
+		{"care_level", patient.CareLevel},
+		{"transport_disposition", patient.TransportDisposition},
+		{"hospital", patient.DestinationHospital},
+	}
+
+	for _, f := range fields {
+		if err := db.validatePicklistValue(f.category, f.value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// CreatePatient adds a patient record to a Rescue & EMS call
+func (db *DB) CreatePatient(patient *Patient) error {
+	if err := db.requireIncidentSeries(patient.CallID, '3', "patients"); err != nil {
+		return err
+	}
+	if err := db.validatePatient(patient); err != nil {
+		return err
+	}
+
+	result, err := db.Exec(`
+		INSERT INTO call_patients (
+			call_id, age_range, chief_complaint, care_level,
+			transport_disposition, destination_hospital, treating_responder_id
+		) VALUES (?, ?, ?, ?, ?, ?, ?)
+	`, patient.CallID, patient.AgeRange, patient.ChiefComplaint, patient.CareLevel,
+		patient.TransportDisposition, patient.DestinationHospital, patient.TreatingResponderID)
+	if err != nil {
+		return fmt.Errorf("failed to create patient: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+	patient.ID = int(id)
+	return nil
+}
+
+// UpdatePatient updates an existing patient record. A patient stays on the
+// call they were added to.
+func (db *DB) UpdatePatient(patient *Patient) error {
+	err := db.QueryRow("SELECT call_id FROM call_patients WHERE id = ?", patient.ID).Scan(&patient.CallID)
+	if err == sql.ErrNoRows {
+		return errors.New("patient not found")
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := db.validatePatient(patient); err != nil {
+		return err
+	}
+
+	_, err = db.Exec(`
+		UPDATE call_patients SET
+			age_range = ?, chief_complaint = ?, care_level = ?,
+			transport_disposition = ?, destination_hospital = ?, treating_responder_id = ?
+		WHERE id = ?
+	`, patient.AgeRange, patient.ChiefComplaint, patient.CareLevel,
+		patient.TransportDisposition, patient.DestinationHospital, patient.TreatingResponderID,
+		patient.ID)
+	return err
+}
+
+// DeletePatient removes a patient record
+func (db *DB) DeletePatient(id int) error {
+	_, err := db.Exec("DELETE FROM call_patients WHERE id = ?", id)
+	return err
+}
+
+// GetPatientsByCallID returns the patients recorded on a call
+func (db *DB) GetPatientsByCallID(callID int) ([]Patient, error) {
+	patients, err := db.GetPatientsForCalls([]int{callID})
+	if err != nil {
+		return nil, err
+	}
+	return patients[callID], nil
+}
+
+// GetPatientsForCalls returns patients for several calls keyed by call ID
+func (db *DB) GetPatientsForCalls(callIDs []int) (map[int][]Patient, error) {
+	patients := make(map[int][]Patient)
+	if len(callIDs) == 0 {
+		return patients, nil
+	}
+
+	args := make([]interface{}, len(callIDs))
+	for i, id := range callIDs {
+		args[i] = id
+	}
+
+	rows, err := db.Query(`
+		SELECT id, call_id, age_range, chief_complaint, care_level,
+		       transport_disposition, destination_hospital, treating_responder_id, created_at
+		FROM call_patients
+		WHERE call_id IN (`+placeholders(len(callIDs))+`)
+		ORDER BY call_id, id
+	`, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var p Patient
+		err := rows.Scan(&p.ID, &p.CallID, &p.AgeRange, &p.ChiefComplaint, &p.CareLevel,
+			&p.TransportDisposition, &p.DestinationHospital, &p.TreatingResponderID, &p.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		patients[p.CallID] = append(patients[p.CallID], p)
+	}
+	return patients, rows.Err()
+}
+
+// Summary returns a one-line description of the patient for reports
+func (p Patient) Summary() string {
+	var parts []string
+	for _, s := range []string{p.AgeRange, p.ChiefComplaint, p.CareLevel} {
+		if s != "" {
+			parts = append(parts, s)
+		}
+	}
+	summary := strings.Join(parts, ", ")
+	if p.TransportDisposition != "" {
+		summary += " - " + p.TransportDisposition
+	}
+	if p.DestinationHospital != "" {
+		summary += " to " + p.DestinationHospital
+	}
+	return summary
+}
@@ -0,0 +1,117 @@
+package db
+
+import (
+	"testing"
+	"time"
+)
+
+// createTestCall inserts a minimal call for tests that need a parent record
+func createTestCall(t *testing.T, db *DB, callType, address string, dispatched time.Time) *Call {
+	t.Helper()
+	call := &Call{
+		CallType:   callType,
+		Address:    address,
+		Town:       "Stamford",
+		Dispatched: dispatched,
+		Narrative:  "Test narrative",
+		CreatedBy:  1,
+	}
+	if err := db.CreateCall(call, nil, nil, nil); err != nil {
+		t.Fatalf("Failed to create test call: %v", err)
+	}
+	return call
+}
+
+func TestCreateAndGetPatients(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	responderID := 1
+	call := &Call{
+		CallType:   "Medical Emergency",
+		Address:    "12 Main St",
+		Town:       "Stamford",
+		Dispatched: time.Now(),
+		Narrative:  "Test narrative",
+		CreatedBy:  1,
+	}
+	if err := db.CreateCall(call, nil, []int{responderID}, nil); err != nil {
+		t.Fatalf("Failed to create call: %v", err)
+	}
+
+	patient := &Patient{
+		CallID:               call.ID,
+		AgeRange:             "Adult (18-64)",
+		ChiefComplaint:       "Chest pain",
+		CareLevel:            "BLS",
+		TransportDisposition: "Transported by Other Agency",
+		DestinationHospital:  "Southwestern Vermont Medical Center",
+		TreatingResponderID:  &responderID,
+	}
+	if err := db.CreatePatient(patient); err != nil {
+		t.Fatalf("Failed to create patient: %v", err)
+	}
+
+	patients, err := db.GetPatientsByCallID(call.ID)
+	if err != nil {
+		t.Fatalf("Failed to get patients: %v", err)
+	}
+	if len(patients) != 1 {
+		t.Fatalf("Expected 1 patient, got %d", len(patients))
+	}
+	if patients[0].ChiefComplaint != "Chest pain" {
+		t.Errorf("Expected chief complaint %q, got %q", "Chest pain", patients[0].ChiefComplaint)
+	}
+	if patients[0].TreatingResponderID == nil || *patients[0].TreatingResponderID != responderID {
+		t.Errorf("Expected treating responder %d, got %v", responderID, patients[0].TreatingResponderID)
+	}
+}
+
+func TestCreatePatientRejectsUnknownHospital(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	call := createTestCall(t, db, "Medical Emergency", "12 Main St", time.Now())
+
+	patient := &Patient{
+		CallID:              call.ID,
+		DestinationHospital: "Nowhere General",
+	}
+	if err := db.CreatePatient(patient); err == nil {
+		t.Error("Expected error for hospital not in picklist")
+	}
+}
+
+func TestCreatePatientChecksCallAndResponder(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	fire := createTestCall(t, db, "Structure Fire", "3 Oak St", time.Now())
+	if err := db.CreatePatient(&Patient{CallID: fire.ID}); err == nil {
+		t.Error("Expected error adding a patient to a fire call")
+	}
+	if err := db.CreatePatient(&Patient{CallID: 9999}); err == nil {
+		t.Error("Expected error adding a patient to a missing call")
+	}
+
+	call := createTestCall(t, db, "Medical Emergency", "12 Main St", time.Now())
+	stranger := 1
+	if err := db.CreatePatient(&Patient{CallID: call.ID, TreatingResponderID: &stranger}); err == nil {
+		t.Error("Expected error for a treating responder not on the call")
+	}
+
+	patient := &Patient{CallID: call.ID, ChiefComplaint: "Fall"}
+	if err := db.CreatePatient(patient); err != nil {
+		t.Fatalf("Failed to create patient: %v", err)
+	}
+	patient.CallID = fire.ID
+	if err := db.UpdatePatient(patient); err != nil {
+		t.Fatalf("Failed to update patient: %v", err)
+	}
+	if patient.CallID != call.ID {
+		t.Errorf("Expected patient to stay on call %d, got %d", call.ID, patient.CallID)
+	}
+	if err := db.UpdatePatient(&Patient{ID: 9999}); err == nil {
+		t.Error("Expected error updating a missing patient")
+	}
+}
@@ -2,12 +2,13 @@ package db
 
 import (
 	"database/sql"
+	"fmt"
 )
 
 // GetPicklistByCategory returns all active picklist items for a category
 func (db *DB) GetPicklistByCategory(category string) ([]Picklist, error) {
 	rows, err := db.Query(`
-		SELECT id, category, value, sort_order, active
+		SELECT id, category, value, sort_order, active, code, code_description
 		FROM picklists 
 		WHERE category = ? AND active = 1 
 		ORDER BY sort_order, value
@@ -20,7 +21,7 @@ func (db *DB) GetPicklistByCategory(category string) ([]Picklist, error) {
 	var items []Picklist
 	for rows.Next() {
 		var item Picklist
-		err := rows.Scan(&item.ID, &item.Category, &item.Value, &item.SortOrder, &item.Active)
+		err := rows.Scan(&item.ID, &item.Category, &item.Value, &item.SortOrder, &item.Active, &item.Code, &item.CodeDescription)
 		if err != nil {
 			return nil, err
 		}
@@ -56,7 +57,7 @@ func (db *DB) GetAllPicklistCategories() ([]string, error) {
 // GetPicklistsByCategoryForAdmin returns all picklist items for a category (including inactive for admin)
 func (db *DB) GetPicklistsByCategoryForAdmin(category string) ([]Picklist, error) {
 	rows, err := db.Query(`
-		SELECT id, category, value, sort_order, active
+		SELECT id, category, value, sort_order, active, code, code_description
 		FROM picklists 
 		WHERE category = ?
 		ORDER BY sort_order, value
@@ -69,7 +70,7 @@ func (db *DB) GetPicklistsByCategoryForAdmin(category string) ([]Picklist, error
 	var items []Picklist
 	for rows.Next() {
 		var item Picklist
-		err := rows.Scan(&item.ID, &item.Category, &item.Value, &item.SortOrder, &item.Active)
+		err := rows.Scan(&item.ID, &item.Category, &item.Value, &item.SortOrder, &item.Active, &item.Code, &item.CodeDescription)
 		if err != nil {
 			return nil, err
 		}
@@ -78,8 +79,12 @@ func (db *DB) GetPicklistsByCategoryForAdmin(category string) ([]Picklist, error
 	return items, nil
 }
 
-// CreatePicklistItem creates a new picklist item
+// CreatePicklistItem creates a new picklist item. Apparatus are kept in
+// their own table, so the retired "apparatus" category is rejected.
 func (db *DB) CreatePicklistItem(category, value string, sortOrder int) error {
+	if category == "apparatus" {
+		return fmt.Errorf("apparatus are managed on the apparatus page, not as picklist items")
+	}
 	_, err := db.Exec(`
 		INSERT INTO picklists (category, value, sort_order, active) 
 		VALUES (?, ?, ?, 1)
@@ -111,9 +116,9 @@ func (db *DB) DeletePicklistItem(id int) error {
 func (db *DB) GetPicklistItem(id int) (*Picklist, error) {
 	var item Picklist
 	err := db.QueryRow(`
-		SELECT id, category, value, sort_order, active
+		SELECT id, category, value, sort_order, active, code, code_description
 		FROM picklists WHERE id = ?
-	`, id).Scan(&item.ID, &item.Category, &item.Value, &item.SortOrder, &item.Active)
+	`, id).Scan(&item.ID, &item.Category, &item.Value, &item.SortOrder, &item.Active, &item.Code, &item.CodeDescription)
 	
 	if err == sql.ErrNoRows {
 		return nil, nil
@@ -123,3 +128,23 @@ func (db *DB) GetPicklistItem(id int) (*Picklist, error) {
 	}
 	return &item, nil
 }
+
+// validatePicklistValue returns an error if value is not an active item in
+// the picklist category. Empty values are allowed for optional fields.
+func (db *DB) validatePicklistValue(category, value string) error {
+	if value == "" {
+		return nil
+	}
+
+	var exists bool
+	err := db.QueryRow(`
+		SELECT EXISTS(SELECT 1 FROM picklists WHERE category = ? AND value = ? AND active = 1)
+	`, category, value).Scan(&exists)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return fmt.Errorf("invalid %s value: %q", category, value)
+	}
+	return nil
+}
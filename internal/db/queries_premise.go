@@ -0,0 +1,147 @@
+package db
+
+import (
+	"strings"
+)
+
+// addressAbbreviations maps street suffixes and directions to the short form
+// used in normalized address keys
+var addressAbbreviations = map[string]string{
+	"road":      "rd",
+	"street":    "st",
+	"avenue":    "ave",
+	"av":        "ave",
+	"drive":     "dr",
+	"lane":      "ln",
+	"court":     "ct",
+	"place":     "pl",
+	"circle":    "cir",
+	"terrace":   "ter",
+	"boulevard": "blvd",
+	"parkway":   "pkwy",
+	"highway":   "hwy",
+	"route":     "rt",
+	"rte":       "rt",
+	"trail":     "trl",
+	"extension": "ext",
+	"mountain":  "mtn",
+	"north":     "n",
+	"south":     "s",
+	"east":      "e",
+	"west":      "w",
+}
+
+// NormalizeAddress builds the key used to match calls at the same premise.
+// It lowercases the address, drops punctuation, collapses whitespace and
+// abbreviates street suffixes and directions, so "12 Main Road" and
+// "12  main rd." produce the same key.
+func NormalizeAddress(address string) string {
+	cleaned := strings.Map(func(r rune) rune {
+		switch r {
+		case '.', ',', '#', ';', ':':
+			return ' '
+		}
+		return r
+	}, strings.ToLower(address))
+
+	words := strings.Fields(cleaned)
+	for i, word := range words {
+		if short, ok := addressAbbreviations[word]; ok {
+			words[i] = short
+		}
+	}
+	return strings.Join(words, " ")
+}
+
+// ensureAddressKeys adds the address_key column and backfills it for
+// existing calls (migration for existing databases)
+func (db *DB) ensureAddressKeys() error {
+	if err := db.ensureColumn("calls", "address_key", "TEXT NOT NULL DEFAULT ''"); err != nil {
+		return err
+	}
+	if _, err := db.Exec("CREATE INDEX IF NOT EXISTS idx_calls_address_key ON calls(address_key)"); err != nil {
+		return err
+	}
+
+	rows, err := db.Query("SELECT id, address FROM calls WHERE address_key = ''")
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	keys := make(map[int]string)
+	for rows.Next() {
+		var id int
+		var address string
+		if err := rows.Scan(&id, &address); err != nil {
+			return err
+		}
+		keys[id] = NormalizeAddress(address)
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	rows.Close()
+
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for id, key := range keys {
+		if _, err := tx.Exec("UPDATE calls SET address_key = ? WHERE id = ?", key, id); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// GetPremiseHistory returns a page of the calls at the same normalized
+// address, newest first, with counts by call type across all of them. Town
+// is matched case-insensitively when given.
+func (db *DB) GetPremiseHistory(address, town, cursor string, limit int) (*PremiseHistory, error) {
+	history := &PremiseHistory{
+		AddressKey: NormalizeAddress(address),
+		Town:       town,
+	}
+	if history.AddressKey == "" {
+		return history, nil
+	}
+
+	filter := CallFilter{AddressKey: history.AddressKey}
+	if town != "" {
+		filter.Towns = []string{town}
+	}
+	page, err := db.SearchCallsPage(filter, cursor, limit)
+	if err != nil {
+		return nil, err
+	}
+	history.Calls, history.Total, history.NextCursor = page.Items, page.Total, page.NextCursor
+
+	// Most recently seen call types first
+	where, args, err := filter.where()
+	if err != nil {
+		return nil, err
+	}
+	rows, err := db.Query(`
+		SELECT call_type, COUNT(*)
+		FROM calls
+		WHERE 1=1`+where+`
+		GROUP BY call_type
+		ORDER BY MAX(dispatched) DESC
+	`, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var count CallTypeCount
+		if err := rows.Scan(&count.CallType, &count.Count); err != nil {
+			return nil, err
+		}
+		history.CountsByType = append(history.CountsByType, count)
+	}
+	return history, rows.Err()
+}
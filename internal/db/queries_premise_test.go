@@ -0,0 +1,92 @@
+package db
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNormalizeAddress(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"12 Main Road", "12 main rd"},
+		{"12  main rd.", "12 main rd"},
+		{"  12 MAIN RD  ", "12 main rd"},
+		{"45 North Street", "45 n st"},
+		{"Route 100, Apt #2", "rt 100 apt 2"},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		if got := NormalizeAddress(tt.input); got != tt.expected {
+			t.Errorf("NormalizeAddress(%q) = %q, expected %q", tt.input, got, tt.expected)
+		}
+	}
+}
+
+func TestGetPremiseHistory(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	base := time.Date(2026, 1, 10, 14, 0, 0, 0, time.UTC)
+	createTestCall(t, db, "Alarm Investigation", "12 Main Road", base)
+	createTestCall(t, db, "Alarm Investigation", "12 main rd.", base.Add(24*time.Hour))
+	createTestCall(t, db, "Structure Fire", "12 MAIN RD", base.Add(48*time.Hour))
+	createTestCall(t, db, "Structure Fire", "14 Main Road", base)
+
+	history, err := db.GetPremiseHistory("12 Main Rd", "stamford", "", 2)
+	if err != nil {
+		t.Fatalf("Failed to get premise history: %v", err)
+	}
+	if history.Total != 3 || len(history.Calls) != 2 || history.NextCursor == "" {
+		t.Fatalf("Expected 2 of 3 calls at premise, got %d of %d", len(history.Calls), history.Total)
+	}
+	if history.Calls[0].CallType != "Structure Fire" {
+		t.Errorf("Expected newest call first, got %s", history.Calls[0].CallType)
+	}
+	if len(history.CountsByType) != 2 {
+		t.Fatalf("Expected 2 call types, got %d", len(history.CountsByType))
+	}
+
+	counts := map[string]int{}
+	for _, c := range history.CountsByType {
+		counts[c.CallType] = c.Count
+	}
+	if counts["Alarm Investigation"] != 2 || counts["Structure Fire"] != 1 {
+		t.Errorf("Unexpected counts by type: %v", counts)
+	}
+	if history.CountsByType[0].CallType != "Structure Fire" {
+		t.Errorf("Expected the most recent call type first, got %v", history.CountsByType)
+	}
+
+	rest, err := db.GetPremiseHistory("12 Main Rd", "stamford", history.NextCursor, 2)
+	if err != nil {
+		t.Fatalf("Failed to get next page: %v", err)
+	}
+	if len(rest.Calls) != 1 || rest.NextCursor != "" || rest.Calls[0].Dispatched.Unix() != base.Unix() {
+		t.Errorf("Expected the oldest call on the last page, got %+v", rest.Calls)
+	}
+}
+
+func TestAddressKeyBackfill(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	call := createTestCall(t, db, "Rescue", "7 Elm Street", time.Now())
+	if _, err := db.Exec("UPDATE calls SET address_key = '' WHERE id = ?", call.ID); err != nil {
+		t.Fatalf("Failed to clear address key: %v", err)
+	}
+
+	if err := db.ensureAddressKeys(); err != nil {
+		t.Fatalf("Backfill failed: %v", err)
+	}
+
+	var key string
+	if err := db.QueryRow("SELECT address_key FROM calls WHERE id = ?", call.ID).Scan(&key); err != nil {
+		t.Fatalf("Failed to read address key: %v", err)
+	}
+	if key != "7 elm st" {
+		t.Errorf("Expected backfilled key %q, got %q", "7 elm st", key)
+	}
+}
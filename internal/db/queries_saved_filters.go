@@ -0,0 +1,163 @@
+package db
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"strings"
+)
+
+// savedFilterColumns is the column list read by scanSavedFilter
+const savedFilterColumns = `id, name, owner_id, shared, filter, created_at, updated_at`
+
+// scanSavedFilter scans a row selected with savedFilterColumns into saved
+func scanSavedFilter(row rowScanner, saved *SavedFilter) error {
+	var filter string
+	err := row.Scan(&saved.ID, &saved.Name, &saved.OwnerID, &saved.Shared, &filter, &saved.CreatedAt, &saved.UpdatedAt)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal([]byte(filter), &saved.Filter)
+}
+
+// validateSavedFilter checks a saved filter's name and that its filter
+// can be run, and returns the filter encoded for storage
+func validateSavedFilter(saved *SavedFilter) (string, error) {
+	saved.Name = strings.TrimSpace(saved.Name)
+	if saved.Name == "" {
+		return "", errors.New("saved filter name is required")
+	}
+	if _, _, err := saved.Filter.where(); err != nil {
+		return "", err
+	}
+	if _, err := saved.Filter.orderBy(); err != nil {
+		return "", err
+	}
+
+	encoded, err := json.Marshal(saved.Filter)
+	if err != nil {
+		return "", err
+	}
+	return string(encoded), nil
+}
+
+// CreateSavedFilter saves a named filter for its owner
+func (db *DB) CreateSavedFilter(saved *SavedFilter) error {
+	filter, err := validateSavedFilter(saved)
+	if err != nil {
+		return err
+	}
+
+	result, err := db.Exec(`
+		INSERT INTO saved_filters (name, owner_id, shared, filter)
+		VALUES (?, ?, ?, ?)
+	`, saved.Name, saved.OwnerID, saved.Shared, filter)
+	if err != nil {
+		return err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+	saved.ID = int(id)
+	return nil
+}
+
+// UpdateSavedFilter renames, re-shares or changes the filter of a saved filter
+func (db *DB) UpdateSavedFilter(saved *SavedFilter) error {
+	filter, err := validateSavedFilter(saved)
+	if err != nil {
+		return err
+	}
+
+	_, err = db.Exec(`
+		UPDATE saved_filters
+		SET name = ?, shared = ?, filter = ?, updated_at = CURRENT_TIMESTAMP
+		WHERE id = ?
+	`, saved.Name, saved.Shared, filter, saved.ID)
+	return err
+}
+
+// DeleteSavedFilter removes a saved filter
+func (db *DB) DeleteSavedFilter(id int) error {
+	_, err := db.Exec("DELETE FROM saved_filters WHERE id = ?", id)
+	return err
+}
+
+// GetSavedFilter returns a saved filter by ID, or nil if it does not exist
+func (db *DB) GetSavedFilter(id int) (*SavedFilter, error) {
+	var saved SavedFilter
+	err := scanSavedFilter(db.QueryRow(`
+		SELECT `+savedFilterColumns+`
+		FROM saved_filters WHERE id = ?
+	`, id), &saved)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &saved, nil
+}
+
+// GetSavedFilters returns the filters a user can run: their own, then the
+// department's shared filters
+func (db *DB) GetSavedFilters(userID int) ([]SavedFilter, error) {
+	rows, err := db.Query(`
+		SELECT `+savedFilterColumns+`
+		FROM saved_filters
+		WHERE owner_id = ? OR shared = 1
+		ORDER BY owner_id != ?, name
+	`, userID, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var filters []SavedFilter
+	for rows.Next() {
+		var saved SavedFilter
+		if err := scanSavedFilter(rows, &saved); err != nil {
+			return nil, err
+		}
+		filters = append(filters, saved)
+	}
+	return filters, rows.Err()
+}
+
+// ensureDefaultSavedFilters adds the column holding each user's default
+// call list view (migration for existing databases)
+func (db *DB) ensureDefaultSavedFilters() error {
+	return db.ensureColumn("users", "default_filter_id", "INTEGER REFERENCES saved_filters(id) ON DELETE SET NULL")
+}
+
+// SetDefaultSavedFilter makes a saved filter the view a user's call list
+// opens with. A filterID of 0 clears the default.
+func (db *DB) SetDefaultSavedFilter(userID, filterID int) error {
+	var value interface{}
+	if filterID != 0 {
+		value = filterID
+	}
+	_, err := db.Exec("UPDATE users SET default_filter_id = ? WHERE id = ?", value, userID)
+	return err
+}
+
+// GetDefaultSavedFilter returns a user's default view, or nil if they have
+// none or it is no longer theirs or shared
+func (db *DB) GetDefaultSavedFilter(userID int) (*SavedFilter, error) {
+	var saved SavedFilter
+	err := scanSavedFilter(db.QueryRow(`
+		SELECT f.id, f.name, f.owner_id, f.shared, f.filter, f.created_at, f.updated_at
+		FROM users u
+		JOIN saved_filters f ON f.id = u.default_filter_id
+		WHERE u.id = ? AND (f.owner_id = u.id OR f.shared = 1)
+	`, userID), &saved)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &saved, nil
+}
@@ -0,0 +1,125 @@
+package db
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSavedFiltersVisibilityAndRun(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	if err := db.CreateUser("Jo", "Smith", "member", "EMT", "4321", false); err != nil {
+		t.Fatalf("Failed to create user: %v", err)
+	}
+	users, err := db.GetAllUsers()
+	if err != nil || len(users) < 2 {
+		t.Fatalf("Expected two users, got %d (%v)", len(users), err)
+	}
+	officer, member := users[0].ID, users[1].ID
+
+	base := time.Date(2026, 5, 1, 8, 0, 0, 0, time.UTC)
+	medical := createTestCall(t, db, "Medical Emergency", "4 Hill Rd", base)
+	createTestCall(t, db, "Rescue", "5 Hill Rd", base.Add(time.Hour))
+
+	open := false
+	shared := &SavedFilter{
+		Name:    "Open medical calls",
+		OwnerID: officer,
+		Shared:  true,
+		Filter:  CallFilter{CallTypes: []string{"Medical Emergency"}, HasClearTime: &open, DispatchedFrom: &base},
+	}
+	if err := db.CreateSavedFilter(shared); err != nil {
+		t.Fatalf("Failed to save shared filter: %v", err)
+	}
+	private := &SavedFilter{Name: "My rescues", OwnerID: officer, Filter: CallFilter{CallTypes: []string{"Rescue"}}}
+	if err := db.CreateSavedFilter(private); err != nil {
+		t.Fatalf("Failed to save private filter: %v", err)
+	}
+	if err := db.CreateSavedFilter(&SavedFilter{Name: "Bad", OwnerID: officer, Filter: CallFilter{Status: "smoldering"}}); err == nil {
+		t.Error("Expected error saving a filter with an unknown status")
+	}
+
+	visible, err := db.GetSavedFilters(member)
+	if err != nil {
+		t.Fatalf("Failed to list saved filters: %v", err)
+	}
+	if len(visible) != 1 || visible[0].ID != shared.ID {
+		t.Errorf("Expected member to see only the shared filter, got %+v", visible)
+	}
+	if visible[0].Filter.DispatchedFrom == nil || !visible[0].Filter.DispatchedFrom.Equal(base) {
+		t.Errorf("Filter did not round-trip: %+v", visible[0].Filter)
+	}
+
+	page, err := db.SearchCallsPage(visible[0].Filter, "", 10)
+	if err != nil {
+		t.Fatalf("Failed to run saved filter: %v", err)
+	}
+	if page.Total != 1 || len(page.Items) != 1 || page.Items[0].ID != medical.ID {
+		t.Errorf("Expected only the open medical call, got %d calls", page.Total)
+	}
+}
+
+func TestDefaultSavedFilter(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	if err := db.CreateUser("Jo", "Smith", "member", "EMT", "4321", false); err != nil {
+		t.Fatalf("Failed to create user: %v", err)
+	}
+	users, err := db.GetAllUsers()
+	if err != nil || len(users) < 2 {
+		t.Fatalf("Expected two users, got %d (%v)", len(users), err)
+	}
+	officer, member := users[0].ID, users[1].ID
+
+	if saved, err := db.GetDefaultSavedFilter(member); err != nil || saved != nil {
+		t.Fatalf("Expected no default view, got %+v (%v)", saved, err)
+	}
+
+	shared := &SavedFilter{Name: "Rescues", OwnerID: officer, Shared: true, Filter: CallFilter{CallTypes: []string{"Rescue"}}}
+	if err := db.CreateSavedFilter(shared); err != nil {
+		t.Fatalf("Failed to save shared filter: %v", err)
+	}
+	if err := db.SetDefaultSavedFilter(member, shared.ID); err != nil {
+		t.Fatalf("Failed to set default view: %v", err)
+	}
+	saved, err := db.GetDefaultSavedFilter(member)
+	if err != nil || saved == nil || saved.ID != shared.ID {
+		t.Fatalf("Expected the shared filter as default, got %+v (%v)", saved, err)
+	}
+	if saved.Filter.CallTypes[0] != "Rescue" {
+		t.Errorf("Default view filter did not round-trip: %+v", saved.Filter)
+	}
+	if other, err := db.GetDefaultSavedFilter(officer); err != nil || other != nil {
+		t.Errorf("Expected defaults to be per user, got %+v (%v)", other, err)
+	}
+
+	// An unshared filter is no longer the member's default
+	shared.Shared = false
+	if err := db.UpdateSavedFilter(shared); err != nil {
+		t.Fatalf("Failed to unshare filter: %v", err)
+	}
+	if saved, err := db.GetDefaultSavedFilter(member); err != nil || saved != nil {
+		t.Errorf("Expected unshared default to be hidden, got %+v (%v)", saved, err)
+	}
+
+	// Deleting the filter clears the default
+	if err := db.SetDefaultSavedFilter(officer, shared.ID); err != nil {
+		t.Fatalf("Failed to set default view: %v", err)
+	}
+	if err := db.DeleteSavedFilter(shared.ID); err != nil {
+		t.Fatalf("Failed to delete saved filter: %v", err)
+	}
+	if saved, err := db.GetDefaultSavedFilter(officer); err != nil || saved != nil {
+		t.Errorf("Expected deleted default to be cleared, got %+v (%v)", saved, err)
+	}
+	var defaultID *int
+	if err := db.QueryRow("SELECT default_filter_id FROM users WHERE id = ?", officer).Scan(&defaultID); err != nil || defaultID != nil {
+		t.Errorf("Expected default_filter_id to be NULL, got %v (%v)", defaultID, err)
+	}
+
+	if err := db.SetDefaultSavedFilter(officer, 0); err != nil {
+		t.Errorf("Failed to clear default view: %v", err)
+	}
+}
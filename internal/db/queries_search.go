@@ -0,0 +1,310 @@
+package db
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Snippet markers around matched terms in CallSearchResult.Snippet
+const (
+	SnippetMatchStart = "["
+	SnippetMatchEnd   = "]"
+)
+
+// ensureCallSearchIndex creates the calls_fts full-text index over the
+// narrative, address, location notes and town of each call, with triggers
+// that keep it in sync, and fills it from existing calls when first created
+func (db *DB) ensureCallSearchIndex() error {
+	var exists bool
+	err := db.QueryRow(`
+		SELECT EXISTS(SELECT 1 FROM sqlite_master WHERE type = 'table' AND name = 'calls_fts')
+	`).Scan(&exists)
+	if err != nil || exists {
+		return err
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	// calls_fts is an external-content table: it stores only the index and
+	// reads column text back from calls, so the triggers pass old values
+	// to the 'delete' command before indexing new ones
+	schema := `
+	CREATE VIRTUAL TABLE calls_fts USING fts5(
+		narrative, address, location_notes, town,
+		content='calls', content_rowid='id',
+		tokenize='porter unicode61'
+	);
+
+	CREATE TRIGGER calls_fts_insert AFTER INSERT ON calls BEGIN
+		INSERT INTO calls_fts(rowid, narrative, address, location_notes, town)
+		VALUES (new.id, new.narrative, new.address, new.location_notes, new.town);
+	END;
+
+	CREATE TRIGGER calls_fts_delete AFTER DELETE ON calls BEGIN
+		INSERT INTO calls_fts(calls_fts, rowid, narrative, address, location_notes, town)
+		VALUES ('delete', old.id, old.narrative, old.address, old.location_notes, old.town);
+	END;
+
+	CREATE TRIGGER calls_fts_update AFTER UPDATE OF narrative, address, location_notes, town ON calls BEGIN
+		INSERT INTO calls_fts(calls_fts, rowid, narrative, address, location_notes, town)
+		VALUES ('delete', old.id, old.narrative, old.address, old.location_notes, old.town);
+		INSERT INTO calls_fts(rowid, narrative, address, location_notes, town)
+		VALUES (new.id, new.narrative, new.address, new.location_notes, new.town);
+	END;
+
+	INSERT INTO calls_fts(calls_fts) VALUES ('rebuild');
+	`
+	if _, err := tx.Exec(schema); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// ftsQuery turns search box text into an FTS5 query. Words and "quoted
+// phrases" must all match; a trailing * makes a word a prefix match. Every
+// term is quoted, so punctuation and FTS5 operators in the text are
+// searched for literally instead of causing syntax errors.
+func ftsQuery(text string) string {
+	var terms []string
+	add := func(term string, prefix bool) {
+		term = strings.TrimSpace(strings.TrimRight(term, "*"))
+		if term == "" {
+			return
+		}
+		quoted := `"` + strings.ReplaceAll(term, `"`, `""`) + `"`
+		if prefix {
+			quoted += "*"
+		}
+		terms = append(terms, quoted)
+	}
+
+	for text != "" {
+		text = strings.TrimLeft(text, " \t\n")
+		if text == "" {
+			break
+		}
+		if text[0] == '"' {
+			end := strings.IndexByte(text[1:], '"')
+			if end < 0 {
+				add(text[1:], false)
+				break
+			}
+			add(text[1:end+1], false)
+			text = text[end+2:]
+			continue
+		}
+		end := strings.IndexAny(text, " \t\n")
+		if end < 0 {
+			end = len(text)
+		}
+		word := text[:end]
+		add(word, strings.HasSuffix(word, "*"))
+		text = text[end:]
+	}
+	return strings.Join(terms, " ")
+}
+
+// SearchCallText runs a full-text search over call narratives, addresses,
+// location notes and towns, narrowed by filter. Results are ranked best
+// match first, with a snippet of the matching text; filter.Sort is ignored.
+// Pages are by offset since rank has no stable key.
+func (db *DB) SearchCallText(text string, filter CallFilter, cursor string, limit int) (*CallSearchPage, error) {
+	page := &CallSearchPage{}
+	match := ftsQuery(text)
+	if match == "" {
+		return page, nil
+	}
+
+	filter.Text = ""
+	where, args, err := filter.where()
+	if err != nil {
+		return nil, err
+	}
+	position, err := parseCursor(cursor)
+	if err != nil {
+		return nil, err
+	}
+	if position.afterID != 0 {
+		return nil, errors.New("page cursor does not match the sort order")
+	}
+	limit = pageSize(limit)
+
+	err = db.QueryRow(`
+		SELECT COUNT(*) FROM calls
+		WHERE id IN (SELECT rowid FROM calls_fts WHERE calls_fts MATCH ?)
+	`+where, append([]interface{}{match}, args...)...).Scan(&page.Total)
+	if err != nil {
+		return nil, err
+	}
+
+	// Address hits count double so "dunbar" ranks calls at Dunbar Rd above
+	// narratives that only mention the name
+	query := `
+		SELECT ` + callColumns + `, fts_rank, fts_snippet
+		FROM calls
+		JOIN (
+			SELECT rowid AS fts_id,
+			       bm25(calls_fts, 1.0, 2.0, 1.0, 1.0) AS fts_rank,
+			       snippet(calls_fts, -1, ?, ?, '...', 16) AS fts_snippet
+			FROM calls_fts
+			WHERE calls_fts MATCH ?
+		) ON fts_id = calls.id
+		WHERE 1=1
+	` + where + " ORDER BY fts_rank, dispatched DESC, id DESC LIMIT ? OFFSET ?"
+	args = append([]interface{}{SnippetMatchStart, SnippetMatchEnd, match}, args...)
+	args = append(args, limit+1, position.offset)
+
+	rows, err := db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var result CallSearchResult
+		row := extraScanner{rows, []interface{}{&result.Rank, &result.Snippet}}
+		if err := scanCall(row, &result.Call); err != nil {
+			return nil, err
+		}
+		page.Items = append(page.Items, result)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(page.Items) > limit {
+		page.Items = page.Items[:limit]
+		page.NextCursor = fmt.Sprintf("offset:%d", position.offset+limit)
+	}
+	return page, nil
+}
+
+// extraScanner appends extra destinations to each Scan, for rows that
+// select more than callColumns
+type extraScanner struct {
+	row   rowScanner
+	extra []interface{}
+}
+
+func (s extraScanner) Scan(dest ...interface{}) error {
+	return s.row.Scan(append(dest, s.extra...)...)
+}
+
+// SearchCallMatches returns a page of the calls matching filter along with
+// the filtered responders and apparatus that were on each one, so a list
+// can show why each call matched
+func (db *DB) SearchCallMatches(filter CallFilter, cursor string, limit int) (*CallMatchPage, error) {
+	calls, err := db.SearchCallsPage(filter, cursor, limit)
+	if err != nil {
+		return nil, err
+	}
+	page := &CallMatchPage{Total: calls.Total, NextCursor: calls.NextCursor}
+	if len(calls.Items) == 0 {
+		return page, nil
+	}
+
+	callIDs := make([]int, len(calls.Items))
+	for i, call := range calls.Items {
+		callIDs[i] = call.ID
+	}
+	responders, err := db.getMatchedResponders(callIDs, uniqueIDs(filter.ResponderIDs))
+	if err != nil {
+		return nil, err
+	}
+	apparatus, err := db.getMatchedApparatus(callIDs, uniqueIDs(filter.ApparatusIDs))
+	if err != nil {
+		return nil, err
+	}
+
+	page.Items = make([]CallMatch, len(calls.Items))
+	for i, call := range calls.Items {
+		page.Items[i] = CallMatch{
+			Call:              call,
+			MatchedResponders: responders[call.ID],
+			MatchedApparatus:  apparatus[call.ID],
+		}
+	}
+	return page, nil
+}
+
+// getMatchedResponders returns which of responderIDs were on each call,
+// keyed by call ID
+func (db *DB) getMatchedResponders(callIDs, responderIDs []int) (map[int][]User, error) {
+	matched := make(map[int][]User)
+	if len(callIDs) == 0 || len(responderIDs) == 0 {
+		return matched, nil
+	}
+
+	rows, err := db.Query(`
+		SELECT cr.call_id, u.id, u.first_name, u.last_name, u.position, u.active, u.created
+		FROM call_responders cr
+		JOIN users u ON cr.responder_id = u.id
+		WHERE cr.call_id IN (`+placeholders(len(callIDs))+`)
+		AND cr.responder_id IN (`+placeholders(len(responderIDs))+`)
+		ORDER BY u.last_name, u.first_name
+	`, idArgs(callIDs, responderIDs)...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var callID int
+		var user User
+		if err := rows.Scan(&callID, &user.ID, &user.FirstName, &user.LastName, &user.Position, &user.Active, &user.Created); err != nil {
+			return nil, err
+		}
+		matched[callID] = append(matched[callID], user)
+	}
+	return matched, rows.Err()
+}
+
+// getMatchedApparatus returns which of apparatusIDs were on each call,
+// keyed by call ID
+func (db *DB) getMatchedApparatus(callIDs, apparatusIDs []int) (map[int][]Apparatus, error) {
+	matched := make(map[int][]Apparatus)
+	if len(callIDs) == 0 || len(apparatusIDs) == 0 {
+		return matched, nil
+	}
+
+	rows, err := db.Query(`
+		SELECT `+apparatusColumns+`, call_id
+		FROM apparatus
+		JOIN (
+			SELECT call_id, apparatus_id FROM call_apparatus
+			WHERE call_id IN (`+placeholders(len(callIDs))+`)
+			AND apparatus_id IN (`+placeholders(len(apparatusIDs))+`)
+		) ON apparatus_id = apparatus.id
+		ORDER BY sort_order, name
+	`, idArgs(callIDs, apparatusIDs)...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var callID int
+		var unit Apparatus
+		if err := scanApparatus(extraScanner{rows, []interface{}{&callID}}, &unit); err != nil {
+			return nil, err
+		}
+		matched[callID] = append(matched[callID], unit)
+	}
+	return matched, rows.Err()
+}
+
+// idArgs flattens lists of IDs into query arguments
+func idArgs(lists ...[]int) []interface{} {
+	var args []interface{}
+	for _, ids := range lists {
+		for _, id := range ids {
+			args = append(args, id)
+		}
+	}
+	return args
+}
@@ -0,0 +1,172 @@
+package db
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFTSQuery(t *testing.T) {
+	tests := map[string]string{
+		`chimney fire`:        `"chimney" "fire"`,
+		`"chimney fire" dun*`: `"chimney fire" "dun"*`,
+		`O"Brien AND NEAR(`:   `"O""Brien" "AND" "NEAR("`,
+		`"unclosed phrase`:    `"unclosed phrase"`,
+		`  * "" `:             ``,
+	}
+	for input, want := range tests {
+		if got := ftsQuery(input); got != want {
+			t.Errorf("ftsQuery(%q) = %q, want %q", input, got, want)
+		}
+	}
+}
+
+func TestSearchCallText(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	base := time.Date(2023, 1, 10, 18, 0, 0, 0, time.UTC)
+	chimney := createTestCall(t, db, "Structure Fire", "210 Dunbar Rd", base)
+	chimney.Narrative = "Chimney fire at the Dunbar place, extinguished with dry chemical"
+	if err := db.UpdateCall(chimney, nil, nil, nil); err != nil {
+		t.Fatalf("Failed to update narrative: %v", err)
+	}
+	other := createTestCall(t, db, "Alarm Activation", "5 Main St", base.Add(time.Hour))
+	other.Narrative = "Smoke detector activated, no fire found, mentioned Dunbar family next door"
+	if err := db.UpdateCall(other, nil, nil, nil); err != nil {
+		t.Fatalf("Failed to update narrative: %v", err)
+	}
+
+	page, err := db.SearchCallText("dunbar", CallFilter{}, "", 10)
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+	results := page.Items
+	if len(results) != 2 || results[0].Call.ID != chimney.ID {
+		t.Fatalf("Expected the Dunbar Rd call ranked first, got %+v", results)
+	}
+	if !strings.Contains(results[0].Snippet, SnippetMatchStart+"Dunbar"+SnippetMatchEnd) {
+		t.Errorf("Expected highlighted snippet, got %q", results[0].Snippet)
+	}
+
+	page, err = db.SearchCallText(`"chimney fire" extinguish*`, CallFilter{}, "", 10)
+	if err != nil {
+		t.Fatalf("Phrase search failed: %v", err)
+	}
+	results = page.Items
+	if len(results) != 1 || results[0].Call.ID != chimney.ID {
+		t.Errorf("Expected only the chimney fire for phrase and prefix, got %d results", len(results))
+	}
+
+	// Edits and deletes are picked up by the triggers
+	other.Narrative = "False alarm"
+	if err := db.UpdateCall(other, nil, nil, nil); err != nil {
+		t.Fatalf("Failed to update narrative: %v", err)
+	}
+	if _, err := db.Exec("DELETE FROM calls WHERE id = ?", chimney.ID); err != nil {
+		t.Fatalf("Failed to delete call: %v", err)
+	}
+	page, err = db.SearchCallText("dunbar", CallFilter{}, "", 10)
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+	results = page.Items
+	if len(results) != 0 {
+		t.Errorf("Expected no results after edit and delete, got %d", len(results))
+	}
+
+	calls, err := db.SearchCalls(CallFilter{Text: "false alarm"}, 10, 0)
+	if err != nil {
+		t.Fatalf("Filter search failed: %v", err)
+	}
+	if len(calls) != 1 || calls[0].ID != other.ID {
+		t.Errorf("Expected filter text to use the index, got %d calls", len(calls))
+	}
+}
+
+func TestCallSearchIndexBackfill(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	call := createTestCall(t, db, "Rescue", "1 Quarry Rd", time.Now())
+	if _, err := db.Exec("DROP TABLE calls_fts"); err != nil {
+		t.Fatalf("Failed to drop index: %v", err)
+	}
+	for _, trigger := range []string{"calls_fts_insert", "calls_fts_delete", "calls_fts_update"} {
+		if _, err := db.Exec("DROP TRIGGER " + trigger); err != nil {
+			t.Fatalf("Failed to drop trigger: %v", err)
+		}
+	}
+
+	if err := db.ensureCallSearchIndex(); err != nil {
+		t.Fatalf("Failed to rebuild index: %v", err)
+	}
+	page, err := db.SearchCallText("quarry", CallFilter{}, "", 10)
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+	results := page.Items
+	if len(results) != 1 || results[0].Call.ID != call.ID {
+		t.Errorf("Expected existing call to be backfilled, got %d results", len(results))
+	}
+}
+
+func TestSearchCallMatchesAnyAndAll(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	if err := db.CreateUser("Jo", "Smith", "member", "EMT", "4321", false); err != nil {
+		t.Fatalf("Failed to create user: %v", err)
+	}
+	users, err := db.GetAllUsers()
+	if err != nil || len(users) < 2 {
+		t.Fatalf("Expected two users, got %d (%v)", len(users), err)
+	}
+	units, err := db.GetActiveApparatus()
+	if err != nil || len(units) < 2 {
+		t.Fatalf("Expected seeded apparatus, got %d (%v)", len(units), err)
+	}
+	first, second := users[0].ID, users[1].ID
+	engine, tanker := units[0].ID, units[1].ID
+
+	base := time.Date(2026, 7, 1, 9, 0, 0, 0, time.UTC)
+	both := &Call{CallType: "Rescue", Address: "1 Pine St", Town: "Stamford", Dispatched: base, Narrative: "Both", CreatedBy: 1}
+	if err := db.CreateCall(both, []int{engine, tanker}, []int{first, second}, nil); err != nil {
+		t.Fatalf("Failed to create call: %v", err)
+	}
+	one := &Call{CallType: "Rescue", Address: "2 Pine St", Town: "Stamford", Dispatched: base.Add(time.Hour), Narrative: "One", CreatedBy: 1}
+	if err := db.CreateCall(one, []int{engine}, []int{second}, nil); err != nil {
+		t.Fatalf("Failed to create call: %v", err)
+	}
+	createTestCall(t, db, "Rescue", "3 Pine St", base.Add(2*time.Hour))
+
+	anyOfPage, err := db.SearchCallMatches(CallFilter{ResponderIDs: []int{first, second}}, "", 10)
+	if err != nil {
+		t.Fatalf("Any-of search failed: %v", err)
+	}
+	anyOf := anyOfPage.Items
+	if len(anyOf) != 2 {
+		t.Fatalf("Expected 2 calls with either responder, got %d", len(anyOf))
+	}
+	if anyOf[0].Call.ID != one.ID || len(anyOf[0].MatchedResponders) != 1 || anyOf[0].MatchedResponders[0].ID != second {
+		t.Errorf("Expected newest call to show only the matched responder, got %+v", anyOf[0].MatchedResponders)
+	}
+
+	allOfPage, err := db.SearchCallMatches(CallFilter{ResponderIDs: []int{first, second, second}, AllResponders: true}, "", 10)
+	if err != nil {
+		t.Fatalf("All-of search failed: %v", err)
+	}
+	allOf := allOfPage.Items
+	if len(allOf) != 1 || allOf[0].Call.ID != both.ID || len(allOf[0].MatchedResponders) != 2 {
+		t.Errorf("Expected only the call with both responders, got %+v", allOf)
+	}
+
+	unitsPage, err := db.SearchCallMatches(CallFilter{ApparatusIDs: []int{tanker, engine}, AllApparatus: true}, "", 10)
+	if err != nil {
+		t.Fatalf("Apparatus search failed: %v", err)
+	}
+	bothUnits := unitsPage.Items
+	if len(bothUnits) != 1 || len(bothUnits[0].MatchedApparatus) != 2 || len(bothUnits[0].MatchedResponders) != 0 {
+		t.Errorf("Expected one call with both units matched, got %+v", bothUnits)
+	}
+}
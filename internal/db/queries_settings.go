@@ -0,0 +1,67 @@
+package db
+
+import (
+	"database/sql"
+	"strconv"
+)
+
+// ensureSetting inserts a default value for a setting added after a
+// database was first created. Existing values are left alone.
+func (db *DB) ensureSetting(key, value string) error {
+	_, err := db.Exec(`
+		INSERT OR IGNORE INTO settings (key, value) VALUES (?, ?)
+	`, key, value)
+	return err
+}
+
+// GetSettings returns all settings
+func (db *DB) GetSettings() ([]Setting, error) {
+	rows, err := db.Query("SELECT key, value FROM settings ORDER BY key")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var settings []Setting
+	for rows.Next() {
+		var setting Setting
+		if err := rows.Scan(&setting.Key, &setting.Value); err != nil {
+			return nil, err
+		}
+		settings = append(settings, setting)
+	}
+	return settings, nil
+}
+
+// GetSetting returns a setting value, or an empty string if it is not set
+func (db *DB) GetSetting(key string) (string, error) {
+	var value string
+	err := db.QueryRow("SELECT value FROM settings WHERE key = ?", key).Scan(&value)
+	if err == sql.ErrNoRows {
+		return "", nil
+	}
+	return value, err
+}
+
+// GetIntSetting returns a setting parsed as an integer, falling back to
+// def when it is missing or not a number
+func (db *DB) GetIntSetting(key string, def int) int {
+	value, err := db.GetSetting(key)
+	if err != nil {
+		return def
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return def
+	}
+	return n
+}
+
+// UpdateSetting creates or updates a setting
+func (db *DB) UpdateSetting(key, value string) error {
+	_, err := db.Exec(`
+		INSERT INTO settings (key, value) VALUES (?, ?)
+		ON CONFLICT(key) DO UPDATE SET value = excluded.value
+	`, key, value)
+	return err
+}
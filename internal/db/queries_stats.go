@@ -0,0 +1,233 @@
+package db
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// statsWeekdays labels SQLite's strftime('%w') day numbers
+var statsWeekdays = []string{"Sunday", "Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday"}
+
+// ensureMutualAidValues replaces the old "No"/"Yes" mutual aid answers with
+// None/Given/Received. The wizard used to ask "Did we receive mutual aid?",
+// so a stored "Yes" means aid was received (migration for existing databases).
+func (db *DB) ensureMutualAidValues() error {
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for _, rename := range []struct{ from, to string }{
+		{"No", MutualAidNone},
+		{"Yes", MutualAidReceived},
+	} {
+		if _, err := tx.Exec("UPDATE calls SET mutual_aid = ? WHERE mutual_aid = ?", rename.to, rename.from); err != nil {
+			return err
+		}
+	}
+
+	// Rename the old picklist rows in place so an admin's active flags carry
+	// over, then add any value still missing
+	for _, rename := range []struct{ from, to string }{
+		{"No", MutualAidNone},
+		{"Yes", MutualAidGiven},
+	} {
+		_, err := tx.Exec(`
+			UPDATE picklists SET value = ?
+			WHERE category = 'mutual_aid' AND value = ?
+			  AND NOT EXISTS (SELECT 1 FROM picklists WHERE category = 'mutual_aid' AND value = ?)
+		`, rename.to, rename.from, rename.to)
+		if err != nil {
+			return err
+		}
+	}
+	for i, value := range []string{MutualAidNone, MutualAidGiven, MutualAidReceived} {
+		_, err := tx.Exec(`
+			INSERT OR IGNORE INTO picklists (category, value, sort_order, active)
+			VALUES ('mutual_aid', ?, ?, 1)
+		`, value, i+1)
+		if err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// newStatCount compares a current and previous count
+func newStatCount(current, previous int) StatCount {
+	count := StatCount{Current: current, Previous: previous, Change: current - previous}
+	if previous != 0 {
+		percent := float64(count.Change) / float64(previous) * 100
+		count.PercentChange = &percent
+	}
+	return count
+}
+
+// previousPeriod returns the period before [start, end]: the same number
+// of calendar months when the period is whole months, otherwise the same
+// length of time ending just before start
+func previousPeriod(start, end time.Time) (time.Time, time.Time) {
+	after := end.Add(time.Nanosecond)
+	wholeMonths := start.Day() == 1 && after.Day() == 1 &&
+		start.Equal(time.Date(start.Year(), start.Month(), 1, 0, 0, 0, 0, start.Location())) &&
+		after.Equal(time.Date(after.Year(), after.Month(), 1, 0, 0, 0, 0, after.Location()))
+	if wholeMonths {
+		months := (after.Year()-start.Year())*12 + int(after.Month()-start.Month())
+		return start.AddDate(0, -months, 0), start.Add(-time.Nanosecond)
+	}
+	return start.Add(-end.Sub(start) - time.Nanosecond), start.Add(-time.Nanosecond)
+}
+
+// statsMonths lists the "YYYY-MM" keys of the months a period touches
+func statsMonths(start, end time.Time) []string {
+	var months []string
+	month := time.Date(start.Year(), start.Month(), 1, 0, 0, 0, 0, start.Location())
+	for !month.After(end) {
+		months = append(months, month.Format("2006-01"))
+		month = month.AddDate(0, 1, 0)
+	}
+	return months
+}
+
+// GetCallStats counts the calls dispatched between start and end that
+// match filter (whose own dispatch range is replaced), broken down by call
+// type, town, month, day of week and hour of day, with mutual aid given
+// and received, each compared with the previous period. Times are grouped
+// by the wall-clock time the call was logged with.
+func (db *DB) GetCallStats(start, end time.Time, filter CallFilter) (*CallStats, error) {
+	if end.Before(start) {
+		return nil, errors.New("end date is before start date")
+	}
+	prevStart, prevEnd := previousPeriod(start, end)
+	stats := &CallStats{Start: start, End: end, PreviousStart: prevStart, PreviousEnd: prevEnd}
+
+	current, previous := filter, filter
+	current.DispatchedFrom, current.DispatchedTo = &start, &end
+	previous.DispatchedFrom, previous.DispatchedTo = &prevStart, &prevEnd
+
+	// Each breakdown groups by a SQL expression over the calls table;
+	// substr drops the zone that time.Time values are stored with
+	breakdowns := []struct {
+		expr   string
+		counts [2]map[string]int
+	}{
+		{expr: "call_type"},
+		{expr: "COALESCE(town, '')"},
+		{expr: "substr(dispatched, 1, 7)"},
+		{expr: "strftime('%w', substr(dispatched, 1, 19))"},
+		{expr: "strftime('%H', substr(dispatched, 1, 19))"},
+	}
+	var totals, given, received [2]int
+	for i, f := range []CallFilter{current, previous} {
+		where, args, err := f.where()
+		if err != nil {
+			return nil, err
+		}
+
+		err = db.QueryRow(`
+			SELECT COUNT(*),
+			       COALESCE(SUM(mutual_aid = ?), 0),
+			       COALESCE(SUM(mutual_aid = ?), 0)
+			FROM calls WHERE 1=1`+where,
+			append([]interface{}{MutualAidGiven, MutualAidReceived}, args...)...,
+		).Scan(&totals[i], &given[i], &received[i])
+		if err != nil {
+			return nil, err
+		}
+
+		for b := range breakdowns {
+			counts, err := db.countCallsBy(breakdowns[b].expr, where, args)
+			if err != nil {
+				return nil, err
+			}
+			breakdowns[b].counts[i] = counts
+		}
+	}
+
+	stats.Total = newStatCount(totals[0], totals[1])
+	stats.MutualAidGiven = newStatCount(given[0], given[1])
+	stats.MutualAidReceived = newStatCount(received[0], received[1])
+	stats.ByCallType = busiestBuckets(breakdowns[0].counts)
+	stats.ByTown = busiestBuckets(breakdowns[1].counts)
+
+	currentMonths, previousMonths := statsMonths(start, end), statsMonths(prevStart, prevEnd)
+	for i, month := range currentMonths {
+		bucket := StatBucket{Key: month, StatCount: newStatCount(breakdowns[2].counts[0][month], 0)}
+		if i < len(previousMonths) {
+			bucket.PreviousKey = previousMonths[i]
+			bucket.StatCount = newStatCount(breakdowns[2].counts[0][month], breakdowns[2].counts[1][previousMonths[i]])
+		}
+		stats.ByMonth = append(stats.ByMonth, bucket)
+	}
+
+	for day, name := range statsWeekdays {
+		key := fmt.Sprintf("%d", day)
+		stats.ByDayOfWeek = append(stats.ByDayOfWeek, StatBucket{
+			Key:       name,
+			StatCount: newStatCount(breakdowns[3].counts[0][key], breakdowns[3].counts[1][key]),
+		})
+	}
+
+	for hour := 0; hour < 24; hour++ {
+		key := fmt.Sprintf("%02d", hour)
+		stats.ByHour = append(stats.ByHour, StatBucket{
+			Key:       key,
+			StatCount: newStatCount(breakdowns[4].counts[0][key], breakdowns[4].counts[1][key]),
+		})
+	}
+	return stats, nil
+}
+
+// countCallsBy counts the calls matching where, grouped by expr
+func (db *DB) countCallsBy(expr, where string, args []interface{}) (map[string]int, error) {
+	// expr comes from GetCallStats, so it is safe to build into SQL
+	rows, err := db.Query(`
+		SELECT `+expr+`, COUNT(*)
+		FROM calls
+		WHERE 1=1`+where+`
+		GROUP BY 1
+	`, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	counts := make(map[string]int)
+	for rows.Next() {
+		var key string
+		var count int
+		if err := rows.Scan(&key, &count); err != nil {
+			return nil, err
+		}
+		counts[key] = count
+	}
+	return counts, rows.Err()
+}
+
+// busiestBuckets compares the keys seen in either period, busiest in the
+// current period first
+func busiestBuckets(counts [2]map[string]int) []StatBucket {
+	var buckets []StatBucket
+	seen := make(map[string]bool)
+	for _, period := range counts {
+		for key := range period {
+			if !seen[key] {
+				seen[key] = true
+				buckets = append(buckets, StatBucket{Key: key, StatCount: newStatCount(counts[0][key], counts[1][key])})
+			}
+		}
+	}
+	sort.Slice(buckets, func(i, j int) bool {
+		if buckets[i].Current != buckets[j].Current {
+			return buckets[i].Current > buckets[j].Current
+		}
+		if buckets[i].Previous != buckets[j].Previous {
+			return buckets[i].Previous > buckets[j].Previous
+		}
+		return buckets[i].Key < buckets[j].Key
+	})
+	return buckets
+}
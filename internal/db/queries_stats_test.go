@@ -0,0 +1,168 @@
+package db
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPreviousPeriod(t *testing.T) {
+	day := func(y int, m time.Month, d int) time.Time { return time.Date(y, m, d, 0, 0, 0, 0, time.UTC) }
+	tests := []struct {
+		name                  string
+		start, end            time.Time
+		wantStart, wantBefore time.Time
+	}{
+		{"year", day(2026, 1, 1), day(2027, 1, 1).Add(-time.Nanosecond), day(2025, 1, 1), day(2026, 1, 1)},
+		{"month", day(2026, 3, 1), day(2026, 4, 1).Add(-time.Nanosecond), day(2026, 2, 1), day(2026, 3, 1)},
+		{"week", day(2026, 3, 8), day(2026, 3, 15).Add(-time.Nanosecond), day(2026, 3, 1), day(2026, 3, 8)},
+	}
+	for _, tt := range tests {
+		start, end := previousPeriod(tt.start, tt.end)
+		if !start.Equal(tt.wantStart) || !end.Equal(tt.wantBefore.Add(-time.Nanosecond)) {
+			t.Errorf("%s: got %v to %v, want %v up to %v", tt.name, start, end, tt.wantStart, tt.wantBefore)
+		}
+	}
+}
+
+func TestGetCallStats(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	// Sunday 2026-03-01 and Monday 2026-02-02, both at 14:00
+	march := time.Date(2026, 3, 1, 14, 0, 0, 0, time.Local)
+	feb := time.Date(2026, 2, 2, 14, 0, 0, 0, time.Local)
+	createTestCall(t, db, "Structure Fire", "1 Main St", march)
+	createTestCall(t, db, "Structure Fire", "2 Main St", march.Add(time.Hour))
+	createTestCall(t, db, "Brush Fire", "5 Main St", feb)
+	createTestCall(t, db, "Brush Fire", "6 Main St", march.AddDate(0, 2, 0))
+
+	// Mutual aid values come from the picklist the call form offers
+	aid, err := db.GetPicklistByCategory("mutual_aid")
+	if err != nil {
+		t.Fatalf("Failed to get mutual aid picklist: %v", err)
+	}
+	var values []string
+	for _, item := range aid {
+		values = append(values, item.Value)
+	}
+	if len(values) != 3 || values[0] != MutualAidNone || values[1] != MutualAidGiven || values[2] != MutualAidReceived {
+		t.Fatalf("Expected None, Given and Received in the mutual aid picklist, got %v", values)
+	}
+	for _, c := range []struct {
+		dispatched time.Time
+		mutualAid  string
+	}{
+		{march.AddDate(0, 0, 1), MutualAidGiven},
+		{feb, MutualAidReceived},
+	} {
+		call := &Call{
+			CallType:   "Rescue",
+			MutualAid:  c.mutualAid,
+			Address:    "3 Main St",
+			Town:       "Stamford",
+			Dispatched: c.dispatched,
+			Narrative:  "Mutual aid rescue",
+			CreatedBy:  1,
+		}
+		if err := db.CreateCall(call, nil, nil, nil); err != nil {
+			t.Fatalf("Failed to create mutual aid call: %v", err)
+		}
+	}
+
+	start := time.Date(2026, 3, 1, 0, 0, 0, 0, time.Local)
+	end := start.AddDate(0, 1, 0).Add(-time.Nanosecond)
+	stats, err := db.GetCallStats(start, end, CallFilter{})
+	if err != nil {
+		t.Fatalf("Failed to get stats: %v", err)
+	}
+
+	if stats.Total.Current != 3 || stats.Total.Previous != 2 || stats.Total.Change != 1 {
+		t.Errorf("Expected 3 calls against 2, got %+v", stats.Total)
+	}
+	if stats.Total.PercentChange == nil || *stats.Total.PercentChange != 50 {
+		t.Errorf("Expected a 50%% change, got %v", stats.Total.PercentChange)
+	}
+	if stats.MutualAidGiven.Current != 1 || stats.MutualAidReceived.Previous != 1 {
+		t.Errorf("Expected mutual aid given 1 now and received 1 before, got %+v %+v", stats.MutualAidGiven, stats.MutualAidReceived)
+	}
+
+	if len(stats.ByCallType) != 3 {
+		t.Fatalf("Expected 3 call types across both periods, got %+v", stats.ByCallType)
+	}
+	if first := stats.ByCallType[0]; first.Key != "Structure Fire" || first.Current != 2 || first.PercentChange != nil {
+		t.Errorf("Expected Structure Fire first with no previous calls, got %+v", first)
+	}
+	if last := stats.ByCallType[2]; last.Key != "Brush Fire" || last.Current != 0 || last.Previous != 1 {
+		t.Errorf("Expected Brush Fire last, only in the previous period, got %+v", last)
+	}
+
+	if len(stats.ByMonth) != 1 || stats.ByMonth[0].Key != "2026-03" || stats.ByMonth[0].PreviousKey != "2026-02" {
+		t.Errorf("Expected March compared with February, got %+v", stats.ByMonth)
+	}
+	if len(stats.ByDayOfWeek) != 7 || stats.ByDayOfWeek[0].Key != "Sunday" || stats.ByDayOfWeek[0].Current != 2 {
+		t.Errorf("Expected 2 Sunday calls, got %+v", stats.ByDayOfWeek)
+	}
+	if monday := stats.ByDayOfWeek[1]; monday.Current != 1 || monday.Previous != 2 {
+		t.Errorf("Expected 1 Monday call against 2, got %+v", monday)
+	}
+	if len(stats.ByHour) != 24 || stats.ByHour[14].Current != 2 || stats.ByHour[15].Current != 1 || stats.ByHour[14].Previous != 2 {
+		t.Errorf("Expected calls at 14:00 and 15:00, got %+v %+v", stats.ByHour[14], stats.ByHour[15])
+	}
+
+	// The filter narrows both periods
+	stats, err = db.GetCallStats(start, end, CallFilter{CallTypes: []string{"Rescue"}})
+	if err != nil {
+		t.Fatalf("Failed to get filtered stats: %v", err)
+	}
+	if stats.Total.Current != 1 || stats.Total.Previous != 1 || len(stats.ByCallType) != 1 {
+		t.Errorf("Expected 1 rescue in each period, got %+v %+v", stats.Total, stats.ByCallType)
+	}
+
+	if _, err := db.GetCallStats(end, start, CallFilter{}); err == nil {
+		t.Error("Expected an error for an end date before the start date")
+	}
+}
+
+func TestMutualAidValuesMigrated(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	// An older database answered "Did we receive mutual aid?" with No/Yes
+	if _, err := db.Exec("DELETE FROM picklists WHERE category = 'mutual_aid'"); err != nil {
+		t.Fatalf("Failed to clear picklist: %v", err)
+	}
+	for i, value := range []string{"No", "Yes"} {
+		if _, err := db.Exec("INSERT INTO picklists (category, value, sort_order, active) VALUES ('mutual_aid', ?, ?, 1)", value, i+1); err != nil {
+			t.Fatalf("Failed to seed old picklist: %v", err)
+		}
+	}
+	none := createTestCall(t, db, "Rescue", "1 Main St", time.Now())
+	received := createTestCall(t, db, "Rescue", "2 Main St", time.Now())
+	for id, value := range map[int]string{none.ID: "No", received.ID: "Yes"} {
+		if _, err := db.Exec("UPDATE calls SET mutual_aid = ? WHERE id = ?", value, id); err != nil {
+			t.Fatalf("Failed to store old answer: %v", err)
+		}
+	}
+
+	if err := db.ensureMutualAidValues(); err != nil {
+		t.Fatalf("Failed to migrate mutual aid values: %v", err)
+	}
+
+	for id, want := range map[int]string{none.ID: MutualAidNone, received.ID: MutualAidReceived} {
+		call, _, _, err := db.GetCallByID(id)
+		if err != nil || call.MutualAid != want {
+			t.Errorf("Expected call %d to have %q, got %+v (%v)", id, want, call, err)
+		}
+	}
+	items, err := db.GetPicklistByCategory("mutual_aid")
+	if err != nil {
+		t.Fatalf("Failed to get picklist: %v", err)
+	}
+	var values []string
+	for _, item := range items {
+		values = append(values, item.Value)
+	}
+	if len(values) != 3 || values[0] != MutualAidNone || values[1] != MutualAidGiven || values[2] != MutualAidReceived {
+		t.Errorf("Expected None, Given and Received, got %v", values)
+	}
+}
@@ -0,0 +1,204 @@
+package db
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/sahilm/fuzzy"
+)
+
+// fuzzyHit is a candidate that matched a pattern, with how closely. Hits
+// rank by tier (0 word prefix, 1 in-order letters, 2 typo), then by score
+// within a tier.
+type fuzzyHit struct {
+	index int
+	tier  int
+	score int
+}
+
+// rankFuzzy returns the candidates matching pattern, best first. Word
+// prefixes rank ahead of sahilm/fuzzy's in-order letter matches ("mn st"
+// for "main st"), which rank ahead of typos within one edit per four
+// letters ("mian st"). Both pattern and candidates should already be
+// normalized the same way.
+func rankFuzzy(pattern string, candidates []string) []fuzzyHit {
+	if pattern == "" {
+		return nil
+	}
+
+	var hits []fuzzyHit
+	matched := make(map[int]bool)
+	for i, candidate := range candidates {
+		if strings.HasPrefix(candidate, pattern) || strings.Contains(candidate, " "+pattern) {
+			hits = append(hits, fuzzyHit{index: i, tier: 0, score: -len(candidate)})
+			matched[i] = true
+		}
+	}
+
+	for _, m := range fuzzy.Find(pattern, candidates) {
+		if !matched[m.Index] {
+			hits = append(hits, fuzzyHit{index: m.Index, tier: 1, score: m.Score})
+			matched[m.Index] = true
+		}
+	}
+
+	patternRunes := []rune(pattern)
+	maxEdits := len(patternRunes) / 4
+	if maxEdits > 2 {
+		maxEdits = 2
+	}
+	if maxEdits > 0 {
+		for i, candidate := range candidates {
+			if matched[i] {
+				continue
+			}
+			if edits := wordPrefixEdits(patternRunes, candidate); edits <= maxEdits {
+				hits = append(hits, fuzzyHit{index: i, tier: 2, score: -edits})
+			}
+		}
+	}
+
+	sort.SliceStable(hits, func(a, b int) bool {
+		if hits[a].tier != hits[b].tier {
+			return hits[a].tier < hits[b].tier
+		}
+		return hits[a].score > hits[b].score
+	})
+	return hits
+}
+
+// wordPrefixEdits returns the fewest edits that turn pattern into the
+// start of candidate from one of its word boundaries
+func wordPrefixEdits(pattern []rune, candidate string) int {
+	best := len(pattern)
+	runes := []rune(candidate)
+	for start := 0; start < len(runes); start++ {
+		if start > 0 && runes[start-1] != ' ' {
+			continue
+		}
+		end := start + len(pattern)
+		if end > len(runes) {
+			end = len(runes)
+		}
+		if edits := editDistance(pattern, runes[start:end]); edits < best {
+			best = edits
+		}
+	}
+	return best
+}
+
+// editDistance is the optimal string alignment distance between a and b:
+// insertions, deletions, substitutions and swaps of neighbouring letters
+// each count as one edit
+func editDistance(a, b []rune) int {
+	d := make([][]int, len(a)+1)
+	for i := range d {
+		d[i] = make([]int, len(b)+1)
+		d[i][0] = i
+	}
+	for j := range d[0] {
+		d[0][j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			d[i][j] = min(d[i-1][j]+1, d[i][j-1]+1, d[i-1][j-1]+cost)
+			if i > 1 && j > 1 && a[i-1] == b[j-2] && a[i-2] == b[j-1] {
+				d[i][j] = min(d[i][j], d[i-2][j-2]+1)
+			}
+		}
+	}
+	return d[len(a)][len(b)]
+}
+
+// SuggestAddresses returns previously used addresses that fuzzily match
+// what has been typed so far, best match first. Addresses are compared by
+// their normalized keys, so "main street", "Main St." and "Mian St" all
+// find "12 Main St"; each premise is suggested once, as most recently
+// written, with its town and how many calls it has had.
+func (db *DB) SuggestAddresses(prefix string, limit int) ([]AddressSuggestion, error) {
+	pattern := NormalizeAddress(prefix)
+	if pattern == "" {
+		return nil, nil
+	}
+
+	// SQLite takes the bare columns from the row holding MAX(dispatched)
+	rows, err := db.Query(`
+		SELECT address_key, address, COALESCE(town, ''), COUNT(*), MAX(dispatched)
+		FROM calls
+		WHERE address_key != ''
+		GROUP BY address_key, town
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var suggestions []AddressSuggestion
+	var keys []string
+	for rows.Next() {
+		var s AddressSuggestion
+		var key, lastUsed string
+		if err := rows.Scan(&key, &s.Address, &s.Town, &s.CallCount, &lastUsed); err != nil {
+			return nil, err
+		}
+		suggestions = append(suggestions, s)
+		keys = append(keys, key)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	// Busier premises come first when matches are otherwise equal
+	hits := rankFuzzy(pattern, keys)
+	sort.SliceStable(hits, func(a, b int) bool {
+		if hits[a].tier != hits[b].tier {
+			return hits[a].tier < hits[b].tier
+		}
+		if hits[a].score != hits[b].score {
+			return hits[a].score > hits[b].score
+		}
+		return suggestions[hits[a].index].CallCount > suggestions[hits[b].index].CallCount
+	})
+
+	limit = pageSize(limit)
+	var ranked []AddressSuggestion
+	for _, hit := range hits {
+		if len(ranked) == limit {
+			break
+		}
+		ranked = append(ranked, suggestions[hit.index])
+	}
+	return ranked, nil
+}
+
+// SuggestResponders returns members whose "first last" name fuzzily
+// matches name, best match first, for picking responders by typing
+func (db *DB) SuggestResponders(name string, limit int) ([]User, error) {
+	pattern := strings.Join(strings.Fields(strings.ToLower(name)), " ")
+	if pattern == "" {
+		return nil, nil
+	}
+
+	users, err := db.GetActiveUsers()
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, len(users))
+	for i, user := range users {
+		names[i] = strings.ToLower(user.FirstName + " " + user.LastName)
+	}
+
+	limit = pageSize(limit)
+	var ranked []User
+	for _, hit := range rankFuzzy(pattern, names) {
+		if len(ranked) == limit {
+			break
+		}
+		ranked = append(ranked, users[hit.index])
+	}
+	return ranked, nil
+}
@@ -0,0 +1,73 @@
+package db
+
+import (
+	"testing"
+	"time"
+)
+
+func TestEditDistance(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"main st", "main st", 0},
+		{"mian st", "main st", 1},
+		{"man st", "main st", 1},
+		{"elm", "oak", 3},
+	}
+	for _, tt := range tests {
+		if got := editDistance([]rune(tt.a), []rune(tt.b)); got != tt.want {
+			t.Errorf("editDistance(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestSuggestAddresses(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	base := time.Date(2026, 2, 1, 12, 0, 0, 0, time.UTC)
+	createTestCall(t, db, "Rescue", "12 Main Street", base)
+	createTestCall(t, db, "Rescue", "12 main st.", base.Add(time.Hour))
+	createTestCall(t, db, "Rescue", "40 Maple Ave", base.Add(2*time.Hour))
+	createTestCall(t, db, "Rescue", "7 Oak Rd", base.Add(3*time.Hour))
+
+	for _, typed := range []string{"main st", "Main Street", "Mian St", "12 ma"} {
+		suggestions, err := db.SuggestAddresses(typed, 10)
+		if err != nil {
+			t.Fatalf("%q: failed to suggest: %v", typed, err)
+		}
+		if len(suggestions) == 0 || suggestions[0].Address != "12 main st." {
+			t.Errorf("%q: expected 12 Main St first, got %+v", typed, suggestions)
+			continue
+		}
+		if suggestions[0].CallCount != 2 || suggestions[0].Town != "Stamford" {
+			t.Errorf("%q: expected one premise with 2 calls in Stamford, got %+v", typed, suggestions[0])
+		}
+		for _, s := range suggestions {
+			if s.Address == "7 Oak Rd" {
+				t.Errorf("%q: did not expect unrelated address %q", typed, s.Address)
+			}
+		}
+	}
+}
+
+func TestSuggestResponders(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	if err := db.CreateUser("Jo", "Smith", "member", "EMT", "4321", false); err != nil {
+		t.Fatalf("Failed to create user: %v", err)
+	}
+	if err := db.CreateUser("Sam", "Jones", "member", "EMT", "5678", false); err != nil {
+		t.Fatalf("Failed to create user: %v", err)
+	}
+
+	users, err := db.SuggestResponders("jo smtih", 10)
+	if err != nil {
+		t.Fatalf("Failed to suggest responders: %v", err)
+	}
+	if len(users) == 0 || users[0].LastName != "Smith" {
+		t.Errorf("Expected Jo Smith first for a misspelled name, got %+v", users)
+	}
+}
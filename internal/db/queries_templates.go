@@ -0,0 +1,183 @@
+package db
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// narrativeTimeFormat is how times are written into rendered narratives
+const narrativeTimeFormat = "01/02/2006 15:04"
+
+// GetNarrativeTemplates returns the active templates for a call type
+func (db *DB) GetNarrativeTemplates(callType string) ([]NarrativeTemplate, error) {
+	return db.queryNarrativeTemplates(`
+		SELECT id, call_type, name, body, sort_order, active
+		FROM narrative_templates
+		WHERE call_type = ? AND active = 1
+		ORDER BY sort_order, name
+	`, callType)
+}
+
+// GetAllNarrativeTemplates returns every template, including inactive ones (for admin)
+func (db *DB) GetAllNarrativeTemplates() ([]NarrativeTemplate, error) {
+	return db.queryNarrativeTemplates(`
+		SELECT id, call_type, name, body, sort_order, active
+		FROM narrative_templates
+		ORDER BY call_type, sort_order, name
+	`)
+}
+
+func (db *DB) queryNarrativeTemplates(query string, args ...interface{}) ([]NarrativeTemplate, error) {
+	rows, err := db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var templates []NarrativeTemplate
+	for rows.Next() {
+		var t NarrativeTemplate
+		if err := rows.Scan(&t.ID, &t.CallType, &t.Name, &t.Body, &t.SortOrder, &t.Active); err != nil {
+			return nil, err
+		}
+		templates = append(templates, t)
+	}
+	return templates, nil
+}
+
+// GetNarrativeTemplate returns a template by ID, or nil if it does not exist
+func (db *DB) GetNarrativeTemplate(id int) (*NarrativeTemplate, error) {
+	var t NarrativeTemplate
+	err := db.QueryRow(`
+		SELECT id, call_type, name, body, sort_order, active
+		FROM narrative_templates WHERE id = ?
+	`, id).Scan(&t.ID, &t.CallType, &t.Name, &t.Body, &t.SortOrder, &t.Active)
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+// CreateNarrativeTemplate creates a new template for a call type
+func (db *DB) CreateNarrativeTemplate(t *NarrativeTemplate) error {
+	if t.CallType == "" || t.Name == "" {
+		return fmt.Errorf("template call type and name are required")
+	}
+	if err := db.validatePicklistValue("call_type", t.CallType); err != nil {
+		return err
+	}
+
+	result, err := db.Exec(`
+		INSERT INTO narrative_templates (call_type, name, body, sort_order, active)
+		VALUES (?, ?, ?, ?, 1)
+	`, t.CallType, t.Name, t.Body, t.SortOrder)
+	if err != nil {
+		return err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+	t.ID = int(id)
+	t.Active = true
+	return nil
+}
+
+// UpdateNarrativeTemplate updates a template
+func (db *DB) UpdateNarrativeTemplate(t *NarrativeTemplate) error {
+	if err := db.validatePicklistValue("call_type", t.CallType); err != nil {
+		return err
+	}
+
+	_, err := db.Exec(`
+		UPDATE narrative_templates
+		SET call_type = ?, name = ?, body = ?, sort_order = ?, active = ?
+		WHERE id = ?
+	`, t.CallType, t.Name, t.Body, t.SortOrder, t.Active, t.ID)
+	return err
+}
+
+// DeleteNarrativeTemplate soft-deletes a template (sets active = false)
+func (db *DB) DeleteNarrativeTemplate(id int) error {
+	_, err := db.Exec("UPDATE narrative_templates SET active = 0 WHERE id = ?", id)
+	return err
+}
+
+// RenderNarrativeTemplate fills a template's placeholders from a draft call
+// and the apparatus and responders selected for it. The template must be for
+// the call's type once one has been chosen.
+func (db *DB) RenderNarrativeTemplate(templateID int, call *Call, apparatusIDs []int, responderIDs []int) (string, error) {
+	t, err := db.GetNarrativeTemplate(templateID)
+	if err != nil {
+		return "", err
+	}
+	if t == nil {
+		return "", fmt.Errorf("narrative template %d not found", templateID)
+	}
+	if call.CallType != "" && call.CallType != t.CallType {
+		return "", fmt.Errorf("narrative template %q is for %s calls, not %s", t.Name, t.CallType, call.CallType)
+	}
+
+	var apparatus []string
+	for _, id := range apparatusIDs {
+		unit, err := db.GetApparatus(id)
+		if err != nil {
+			return "", err
+		}
+		if unit != nil {
+			apparatus = append(apparatus, unit.Name)
+		}
+	}
+
+	var responders []string
+	for _, id := range responderIDs {
+		user, err := db.GetUserByID(id)
+		if err != nil {
+			return "", err
+		}
+		if user != nil {
+			responders = append(responders, user.FirstName+" "+user.LastName)
+		}
+	}
+
+	values := map[string]string{
+		"incident_number": call.IncidentNumber,
+		"call_type":       call.CallType,
+		"mutual_aid":      call.MutualAid,
+		"address":         call.Address,
+		"town":            call.Town,
+		"location_notes":  call.LocationNotes,
+		"apparatus":       strings.Join(apparatus, ", "),
+		"responders":      strings.Join(responders, ", "),
+		"dispatched":      formatNarrativeTime(&call.Dispatched),
+		"enroute":         formatNarrativeTime(call.Enroute),
+		"on_scene":        formatNarrativeTime(call.OnScene),
+		"clear":           formatNarrativeTime(call.Clear),
+	}
+	return FillNarrativePlaceholders(t.Body, values), nil
+}
+
+// FillNarrativePlaceholders replaces {name} placeholders with values.
+// Unknown placeholders are left in place so they stand out for editing.
+func FillNarrativePlaceholders(body string, values map[string]string) string {
+	pairs := make([]string, 0, len(values)*2)
+	for name, value := range values {
+		pairs = append(pairs, "{"+name+"}", value)
+	}
+	return strings.NewReplacer(pairs...).Replace(body)
+}
+
+// formatNarrativeTime formats an optional time for a narrative placeholder
+func formatNarrativeTime(t *time.Time) string {
+	if t == nil || t.IsZero() {
+		return ""
+	}
+	return t.Format(narrativeTimeFormat)
+}
@@ -0,0 +1,75 @@
+package db
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRenderNarrativeTemplate(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	template := &NarrativeTemplate{
+		CallType: "Structure Fire",
+		Name:     "Working fire",
+		Body:     "{apparatus} dispatched {dispatched} to {address}, {town}. Crew: {responders}. Owner: {owner_name}.",
+	}
+	if err := db.CreateNarrativeTemplate(template); err != nil {
+		t.Fatalf("Failed to create template: %v", err)
+	}
+
+	units, err := db.GetAllApparatus()
+	if err != nil || len(units) < 2 {
+		t.Fatalf("Expected seeded apparatus, got %d (%v)", len(units), err)
+	}
+	responder, err := db.GetUserByID(1)
+	if err != nil || responder == nil {
+		t.Fatalf("Failed to get responder: %v", err)
+	}
+
+	dispatched := time.Date(2026, 1, 15, 3, 42, 0, 0, time.Local)
+	call := &Call{CallType: "Structure Fire", Address: "12 Main St", Town: "Stamford", Dispatched: dispatched}
+	narrative, err := db.RenderNarrativeTemplate(template.ID, call, []int{units[0].ID, units[1].ID}, []int{responder.ID})
+	if err != nil {
+		t.Fatalf("Failed to render template: %v", err)
+	}
+
+	want := units[0].Name + ", " + units[1].Name + " dispatched 01/15/2026 03:42 to 12 Main St, Stamford. " +
+		"Crew: " + responder.FirstName + " " + responder.LastName + ". Owner: {owner_name}."
+	if narrative != want {
+		t.Errorf("Expected %q, got %q", want, narrative)
+	}
+}
+
+func TestRenderNarrativeTemplateDraft(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	template := &NarrativeTemplate{CallType: "Rescue", Name: "Rescue", Body: "Cleared {clear} from {address}"}
+	if err := db.CreateNarrativeTemplate(template); err != nil {
+		t.Fatalf("Failed to create template: %v", err)
+	}
+
+	// Placeholders for fields not filled in yet render empty
+	narrative, err := db.RenderNarrativeTemplate(template.ID, &Call{}, nil, nil)
+	if err != nil {
+		t.Fatalf("Failed to render template for empty draft: %v", err)
+	}
+	if narrative != "Cleared  from " {
+		t.Errorf("Expected empty values, got %q", narrative)
+	}
+
+	wrongType := &Call{CallType: "Medical Emergency", Address: "12 Main St"}
+	if _, err := db.RenderNarrativeTemplate(template.ID, wrongType, nil, nil); err == nil || !strings.Contains(err.Error(), "Rescue") {
+		t.Errorf("Expected error for a template of another call type, got %v", err)
+	}
+
+	if _, err := db.RenderNarrativeTemplate(template.ID+100, &Call{}, nil, nil); err == nil {
+		t.Error("Expected error for missing template")
+	}
+
+	if err := db.CreateNarrativeTemplate(&NarrativeTemplate{CallType: "Alien Landing", Name: "UFO"}); err == nil {
+		t.Error("Expected error for a template with an unknown call type")
+	}
+}
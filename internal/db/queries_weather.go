@@ -0,0 +1,87 @@
+package db
+
+import (
+	"fmt"
+)
+
+// Temperature bounds accepted for the conditions at the time of a call
+const (
+	minTemperatureF = -60
+	maxTemperatureF = 130
+)
+
+// ensureWeatherFields adds the weather and road condition columns to calls,
+// seeds their picklists and registers them as optional form fields
+func (db *DB) ensureWeatherFields() error {
+	columns := []struct {
+		name string
+		def  string
+	}{
+		{"weather_temp_f", "INTEGER"},
+		{"weather_wind", "TEXT NOT NULL DEFAULT ''"},
+		{"weather_precipitation", "TEXT NOT NULL DEFAULT ''"},
+		{"road_conditions", "TEXT NOT NULL DEFAULT ''"},
+	}
+	for _, c := range columns {
+		if err := db.ensureColumn("calls", c.name, c.def); err != nil {
+			return err
+		}
+	}
+
+	categories := []struct {
+		category string
+		values   []string
+	}{
+		{"weather_wind", []string{"Calm", "Light (1-10 mph)", "Moderate (11-20 mph)", "Strong (21-30 mph)", "Severe (30+ mph)"}},
+		{"precipitation", []string{"None", "Rain", "Snow", "Sleet/Freezing Rain", "Fog"}},
+		{"road_conditions", []string{"Dry", "Wet", "Snow Covered", "Icy", "Flooded"}},
+	}
+	for _, c := range categories {
+		if err := db.ensurePicklistCategory(c.category, c.values); err != nil {
+			return err
+		}
+	}
+
+	formFields := []struct {
+		fieldName string
+		label     string
+		sortOrder int
+	}{
+		{"temperature_f", "Temperature (°F)", 17},
+		{"wind", "Wind", 18},
+		{"precipitation", "Precipitation", 19},
+		{"road_conditions", "Road Conditions", 20},
+	}
+	for _, field := range formFields {
+		_, err := db.Exec(`
+			INSERT OR IGNORE INTO form_fields (field_name, label, required, enabled, sort_order)
+			VALUES (?, ?, 0, 1, ?)
+		`, field.fieldName, field.label, field.sortOrder)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateWeather checks the optional weather fields on a call
+func (db *DB) validateWeather(call *Call) error {
+	if call.TemperatureF != nil && (*call.TemperatureF < minTemperatureF || *call.TemperatureF > maxTemperatureF) {
+		return fmt.Errorf("temperature must be between %d and %d °F", minTemperatureF, maxTemperatureF)
+	}
+
+	fields := []struct {
+		category string
+		value    string
+	}{
+		{"weather_wind", call.Wind},
+		{"precipitation", call.Precipitation},
+		{"road_conditions", call.RoadConditions},
+	}
+	for _, f := range fields {
+		if err := db.validatePicklistValue(f.category, f.value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,59 @@
+package db
+
+import (
+	"testing"
+	"time"
+)
+
+func TestWeatherFieldsValidatedAndSearchable(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	temp := 28
+	call := &Call{
+		CallType:       "Motor Vehicle Accident",
+		Address:        "Route 7",
+		Town:           "Stamford",
+		Dispatched:     time.Now(),
+		Narrative:      "Single vehicle off road",
+		TemperatureF:   &temp,
+		Wind:           "Calm",
+		Precipitation:  "Snow",
+		RoadConditions: "Icy",
+		CreatedBy:      1,
+	}
+	if err := db.CreateCall(call, nil, nil, nil); err != nil {
+		t.Fatalf("Failed to create call with weather: %v", err)
+	}
+	createTestCall(t, db, "Motor Vehicle Accident", "Route 9", time.Now())
+
+	saved, _, _, err := db.GetCallByID(call.ID)
+	if err != nil {
+		t.Fatalf("Failed to get call: %v", err)
+	}
+	if saved.TemperatureF == nil || *saved.TemperatureF != 28 || saved.RoadConditions != "Icy" {
+		t.Errorf("Weather fields not saved: %+v", saved)
+	}
+
+	freezing := 32
+	calls, err := db.SearchCalls(CallFilter{RoadConditions: "Icy", MaxTemperatureF: &freezing}, 10, 0)
+	if err != nil {
+		t.Fatalf("Failed to search calls: %v", err)
+	}
+	if len(calls) != 1 || calls[0].ID != call.ID {
+		t.Errorf("Expected only the icy call, got %d calls", len(calls))
+	}
+
+	bad := *call
+	bad.Wind = "Hurricane"
+	if err := db.UpdateCall(&bad, nil, nil, nil); err == nil {
+		t.Error("Expected error for wind value not in picklist")
+	}
+
+	bad = *call
+	hot := 200
+	bad.TemperatureF = &hot
+	if err := db.UpdateCall(&bad, nil, nil, nil); err == nil {
+		t.Error("Expected error for out of range temperature")
+	}
+}
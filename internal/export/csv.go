@@ -6,32 +6,109 @@ import (
 	"fmt"
 	"os"
 	"strconv"
+	"strings"
 	"time"
 )
 
-// ExportCallsToCSV exports calls to CSV file
+// CSVOptions controls the optional sections of a call CSV export.
+// The zero value produces the default export.
+type CSVOptions struct {
+	// IncludePatients adds a Patients column built from Patients.
+	// Patient care data is left out of the export unless this is set.
+	IncludePatients bool
+	Patients        map[int][]db.Patient // keyed by call ID
+
+	// CustomFields adds one column per custom field, filled from each
+	// call's CustomFields (see db.AttachCustomValues)
+	CustomFields []db.CustomField
+
+	// InventoryUsage adds a Supplies Used column when not nil
+	InventoryUsage map[int][]db.InventoryUsage // keyed by call ID
+
+	// Parties adds an Involved Parties column when not nil. Fields tagged
+	// as PII are blanked unless IncludePII is set, so only roles and
+	// non-personal details reach non-privileged exports.
+	Parties    map[int][]db.Party // keyed by call ID
+	IncludePII bool
+}
+
+// ExportCallsToCSV exports calls to CSV file. Related incidents come from
+// each call's Links (see db.AttachCallLinks).
 func ExportCallsToCSV(calls []db.Call, filename string) error {
-	file, err := os.Create(filename)
+	return ExportCallsToCSVWithOptions(calls, filename, CSVOptions{})
+}
+
+// ExportCallsToCSVWithOptions exports calls to CSV file with optional sections
+func ExportCallsToCSVWithOptions(calls []db.Call, filename string, opts CSVOptions) error {
+	w, err := NewCallCSVWriter(filename, opts)
 	if err != nil {
 		return err
 	}
-	defer file.Close()
+	if err := w.WriteCalls(calls, CallDetails{
+		Patients:       opts.Patients,
+		InventoryUsage: opts.InventoryUsage,
+		Parties:        opts.Parties,
+	}); err != nil {
+		w.Close()
+		return err
+	}
+	return w.Close()
+}
+
+// CallDetails holds the related records for a batch of calls, keyed by call ID
+type CallDetails struct {
+	Patients       map[int][]db.Patient
+	InventoryUsage map[int][]db.InventoryUsage
+	Parties        map[int][]db.Party
+}
+
+// CallCSVWriter writes calls to a CSV file one page at a time, so large
+// exports never hold every call in memory. The columns are fixed by the
+// options it is created with; the maps in those options only switch the
+// optional columns on, and each page's records come from WriteCalls.
+type CallCSVWriter struct {
+	file   *os.File
+	writer *csv.Writer
+	opts   CSVOptions
+}
 
-	writer := csv.NewWriter(file)
-	defer writer.Flush()
+// NewCallCSVWriter creates filename and writes the header row
+func NewCallCSVWriter(filename string, opts CSVOptions) (*CallCSVWriter, error) {
+	file, err := os.Create(filename)
+	if err != nil {
+		return nil, err
+	}
+	w := &CallCSVWriter{file: file, writer: csv.NewWriter(file), opts: opts}
 
 	// Write header
 	header := []string{
 		"Date", "Time", "Incident #", "Call Type", "Mutual Aid",
 		"Address", "Town", "Location Notes",
 		"Dispatched", "Enroute", "On Scene", "Clear",
-		"Narrative", "Created By",
+		"Narrative", "Created By", "Related Incidents",
 	}
-	if err := writer.Write(header); err != nil {
-		return err
+	for _, field := range opts.CustomFields {
+		header = append(header, field.Label)
+	}
+	if opts.InventoryUsage != nil {
+		header = append(header, "Supplies Used")
 	}
+	if opts.Parties != nil {
+		header = append(header, "Involved Parties")
+	}
+	if opts.IncludePatients {
+		header = append(header, "Patients")
+	}
+	if err := w.writer.Write(header); err != nil {
+		file.Close()
+		return nil, err
+	}
+	return w, nil
+}
 
-	// Write data
+// WriteCalls writes one page of calls with their related records
+func (w *CallCSVWriter) WriteCalls(calls []db.Call, details CallDetails) error {
+	opts := w.opts
 	for _, call := range calls {
 		record := []string{
 			call.CreatedAt.Format("01/02/2006"),
@@ -48,15 +125,67 @@ func ExportCallsToCSV(calls []db.Call, filename string) error {
 			formatTimePtr(call.Clear),
 			call.Narrative,
 			strconv.Itoa(call.CreatedBy),
+			formatLinks(call.Links),
+		}
+		for _, field := range opts.CustomFields {
+			record = append(record, call.CustomFields[field.FieldName])
 		}
-		if err := writer.Write(record); err != nil {
+		if opts.InventoryUsage != nil {
+			var lines []string
+			for _, usage := range details.InventoryUsage[call.ID] {
+				lines = append(lines, usage.Summary())
+			}
+			record = append(record, strings.Join(lines, "; "))
+		}
+		if opts.Parties != nil {
+			record = append(record, formatParties(details.Parties[call.ID], opts.IncludePII))
+		}
+		if opts.IncludePatients {
+			var summaries []string
+			for _, patient := range details.Patients[call.ID] {
+				summaries = append(summaries, patient.Summary())
+			}
+			record = append(record, strings.Join(summaries, "; "))
+		}
+		if err := w.writer.Write(record); err != nil {
 			return err
 		}
 	}
-
 	return nil
 }
 
+// Close flushes the remaining rows and closes the file
+func (w *CallCSVWriter) Close() error {
+	w.writer.Flush()
+	if err := w.writer.Error(); err != nil {
+		w.file.Close()
+		return err
+	}
+	return w.file.Close()
+}
+
+// formatLinks lists related incidents as "rekindle-of 2026-012; ..."
+func formatLinks(links []db.CallLink) string {
+	parts := make([]string, len(links))
+	for i, link := range links {
+		parts[i] = link.LinkType + " " + link.LinkedIncidentNumber
+	}
+	return strings.Join(parts, "; ")
+}
+
+// formatParties lists involved parties as "Driver, Jane Doe, ...; Owner, ...",
+// blanking PII fields unless includePII is set
+func formatParties(parties []db.Party, includePII bool) string {
+	summaries := make([]string, len(parties))
+	for i, party := range parties {
+		if !includePII {
+			db.RedactPII(&party)
+		}
+		summaries[i] = party.Summary()
+	}
+	return strings.Join(summaries, "; ")
+}
+
 func formatTimePtr(t *time.Time) string {
 	if t == nil {
 		return ""
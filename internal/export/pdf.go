@@ -1,14 +1,32 @@
 package export
 
 import (
+	"bytes"
 	"fd-call-log/internal/db"
 	"fmt"
 
 	"github.com/jung-kurt/gofpdf"
 )
 
+// CallPDFSections holds the optional sections of a single call report.
+// Nil or empty sections are left out of the PDF.
+type CallPDFSections struct {
+	FireDetails *db.FireDetails
+	// CustomFields gives the labels and order for the call's custom field values
+	CustomFields []db.CustomField
+	// InventoryUsage lists the supplies and equipment used on the call
+	InventoryUsage []db.InventoryUsage
+	// Parties lists the people involved. Their PII fields are blanked
+	// unless IncludePII is set.
+	Parties    []db.Party
+	IncludePII bool
+	// Images are appended one per page after the report; attachments that
+	// are not PNG, JPEG or GIF images are skipped
+	Images []db.Attachment
+}
+
 // GenerateCallPDF generates a single call report PDF
-func GenerateCallPDF(call *db.Call, apparatus []db.Picklist, responders []db.User, filename string) error {
+func GenerateCallPDF(call *db.Call, apparatus []db.Apparatus, responders []db.User, sections CallPDFSections, filename string) error {
 	pdf := gofpdf.New("P", "mm", "A4", "")
 	pdf.AddPage()
 
@@ -78,6 +96,8 @@ func GenerateCallPDF(call *db.Call, apparatus []db.Picklist, responders []db.Use
 	}
 	pdf.Ln(4)
 
+	writeConditions(pdf, call)
+
 	// Resources
 	pdf.SetFont("Arial", "B", 12)
 	pdf.Cell(40, 8, "Resources")
@@ -92,7 +112,7 @@ func GenerateCallPDF(call *db.Call, apparatus []db.Picklist, responders []db.Use
 			if i > 0 {
 				apparatusText += ", "
 			}
-			apparatusText += app.Value
+			apparatusText += app.Name
 		}
 	}
 	pdf.Cell(140, 6, apparatusText)
@@ -112,6 +132,79 @@ func GenerateCallPDF(call *db.Call, apparatus []db.Picklist, responders []db.Use
 	pdf.Cell(140, 6, respondersText)
 	pdf.Ln(10)
 
+	if sections.FireDetails != nil {
+		writeFireDetails(pdf, sections.FireDetails)
+	}
+
+	if len(sections.Parties) > 0 {
+		pdf.SetFont("Arial", "B", 12)
+		pdf.Cell(40, 8, "Involved Parties")
+		pdf.Ln(10)
+
+		pdf.SetFont("Arial", "", 10)
+		for _, party := range sections.Parties {
+			if !sections.IncludePII {
+				db.RedactPII(&party)
+			}
+			for _, line := range pdf.SplitText(party.Summary(), 190) {
+				pdf.Cell(190, 6, line)
+				pdf.Ln(6)
+			}
+		}
+		pdf.Ln(4)
+	}
+
+	if len(sections.InventoryUsage) > 0 {
+		pdf.SetFont("Arial", "B", 12)
+		pdf.Cell(40, 8, "Supplies Used")
+		pdf.Ln(10)
+
+		pdf.SetFont("Arial", "", 10)
+		for _, usage := range sections.InventoryUsage {
+			pdf.Cell(50, 6, usage.ItemName+":")
+			quantity := fmt.Sprintf("%g %s", usage.Quantity, usage.Unit)
+			if usage.Notes != "" {
+				quantity += " - " + usage.Notes
+			}
+			pdf.Cell(140, 6, quantity)
+			pdf.Ln(6)
+		}
+		pdf.Ln(4)
+	}
+
+	if len(sections.CustomFields) > 0 && len(call.CustomFields) > 0 {
+		pdf.SetFont("Arial", "B", 12)
+		pdf.Cell(40, 8, "Additional Information")
+		pdf.Ln(10)
+
+		pdf.SetFont("Arial", "", 10)
+		for _, field := range sections.CustomFields {
+			value, ok := call.CustomFields[field.FieldName]
+			if !ok {
+				continue
+			}
+			pdf.Cell(50, 6, field.Label+":")
+			pdf.Cell(140, 6, value)
+			pdf.Ln(6)
+		}
+		pdf.Ln(4)
+	}
+
+	if len(call.Links) > 0 {
+		pdf.SetFont("Arial", "B", 12)
+		pdf.Cell(40, 8, "Related Incidents")
+		pdf.Ln(10)
+
+		pdf.SetFont("Arial", "", 10)
+		for _, link := range call.Links {
+			pdf.Cell(50, 6, link.LinkType+":")
+			pdf.Cell(140, 6, fmt.Sprintf("%s - %s (%s)", link.LinkedIncidentNumber, link.LinkedCallType,
+				link.LinkedDispatched.Format("01/02/2006 15:04")))
+			pdf.Ln(6)
+		}
+		pdf.Ln(4)
+	}
+
 	// Narrative
 	pdf.SetFont("Arial", "B", 12)
 	pdf.Cell(40, 8, "Narrative")
@@ -128,11 +221,170 @@ func GenerateCallPDF(call *db.Call, apparatus []db.Picklist, responders []db.Use
 		pdf.Ln(6)
 	}
 
+	for _, image := range sections.Images {
+		appendImage(pdf, image)
+	}
+
 	return pdf.OutputFileAndClose(filename)
 }
 
+// pdfImageTypes maps attachment MIME types to gofpdf image types
+var pdfImageTypes = map[string]string{
+	"image/png":  "PNG",
+	"image/jpeg": "JPG",
+	"image/jpg":  "JPG",
+	"image/gif":  "GIF",
+}
+
+// appendImage adds an image attachment on its own page, scaled to fit the
+// page width, with its caption underneath
+func appendImage(pdf *gofpdf.Fpdf, image db.Attachment) {
+	imageType, ok := pdfImageTypes[image.MimeType]
+	if !ok || len(image.Data) == 0 {
+		return
+	}
+
+	// A document that already failed is left with its error for Save to report
+	if pdf.Err() {
+		return
+	}
+
+	name := fmt.Sprintf("attachment-%d", image.ID)
+	options := gofpdf.ImageOptions{ImageType: imageType, ReadDpi: true}
+	info := pdf.RegisterImageOptionsReader(name, options, bytes.NewReader(image.Data))
+	if info == nil || pdf.Err() {
+		// Skip images gofpdf cannot decode rather than failing the report;
+		// the document had no error before, so this only clears the image's
+		pdf.ClearError()
+		return
+	}
+
+	pdf.AddPage()
+	pdf.SetFont("Arial", "B", 12)
+	pdf.Cell(190, 8, "Attachment: "+image.FileName)
+	pdf.Ln(10)
+
+	width, height := 190.0, 190.0*info.Height()/info.Width()
+	if height > 240 {
+		width, height = width*240/height, 240
+	}
+	pdf.ImageOptions(name, 10, pdf.GetY(), width, height, true, options, 0, "")
+
+	if image.Caption != "" {
+		pdf.SetFont("Arial", "", 10)
+		pdf.Ln(4)
+		for _, line := range pdf.SplitText(image.Caption, 180) {
+			pdf.Cell(190, 6, line)
+			pdf.Ln(6)
+		}
+	}
+}
+
+// writeConditions writes the weather and road conditions section, if any were recorded
+func writeConditions(pdf *gofpdf.Fpdf, call *db.Call) {
+	temperature := ""
+	if call.TemperatureF != nil {
+		temperature = fmt.Sprintf("%d F", *call.TemperatureF)
+	}
+	rows := []struct {
+		label string
+		value string
+	}{
+		{"Temperature:", temperature},
+		{"Wind:", call.Wind},
+		{"Precipitation:", call.Precipitation},
+		{"Road Conditions:", call.RoadConditions},
+	}
+
+	header := false
+	for _, row := range rows {
+		if row.value == "" {
+			continue
+		}
+		if !header {
+			pdf.SetFont("Arial", "B", 12)
+			pdf.Cell(40, 8, "Conditions")
+			pdf.Ln(10)
+			pdf.SetFont("Arial", "", 10)
+			header = true
+		}
+		pdf.Cell(50, 6, row.label)
+		pdf.Cell(140, 6, row.value)
+		pdf.Ln(6)
+	}
+	if header {
+		pdf.Ln(4)
+	}
+}
+
+// writeFireDetails writes the fire cause, origin and loss section
+func writeFireDetails(pdf *gofpdf.Fpdf, details *db.FireDetails) {
+	pdf.SetFont("Arial", "B", 12)
+	pdf.Cell(40, 8, "Fire Cause and Loss")
+	pdf.Ln(10)
+
+	pdf.SetFont("Arial", "", 10)
+	rows := []struct {
+		label string
+		value string
+	}{
+		{"Area of Origin:", details.AreaOfOrigin},
+		{"Heat Source:", details.HeatSource},
+		{"Cause:", details.CauseCategory},
+		{"Detectors:", details.DetectorsPresent},
+		{"Property Loss:", formatDollars(details.PropertyLoss)},
+		{"Contents Loss:", formatDollars(details.ContentsLoss)},
+		{"Total Loss:", formatDollars(details.TotalLoss())},
+		{"Value Saved:", formatDollars(details.ValueSaved)},
+	}
+	for _, row := range rows {
+		pdf.Cell(50, 6, row.label)
+		pdf.Cell(140, 6, row.value)
+		pdf.Ln(6)
+	}
+
+	if details.Notes != "" {
+		for _, line := range pdf.SplitText(details.Notes, 180) {
+			pdf.Cell(190, 6, line)
+			pdf.Ln(6)
+		}
+	}
+	pdf.Ln(4)
+}
+
+// formatDollars formats whole dollars with thousands separators (e.g. $12,500)
+func formatDollars(amount int) string {
+	sign := ""
+	if amount < 0 {
+		sign = "-"
+		amount = -amount
+	}
+	digits := fmt.Sprintf("%d", amount)
+	for i := len(digits) - 3; i > 0; i -= 3 {
+		digits = digits[:i] + "," + digits[i:]
+	}
+	return sign + "$" + digits
+}
+
 // GenerateCallLogPDF generates a tabular call log PDF
 func GenerateCallLogPDF(calls []db.Call, filename string, startDate, endDate string) error {
+	callLog := NewCallLogPDF(startDate, endDate)
+	callLog.AddCalls(calls)
+	return callLog.Save(filename)
+}
+
+// CallLogPDF builds a tabular call log a page of calls at a time
+type CallLogPDF struct {
+	pdf *gofpdf.Fpdf
+}
+
+var (
+	callLogHeaders = []string{"Date", "Time", "Address", "Town", "Type", "Disposition"}
+	callLogWidths  = []float64{25, 15, 80, 40, 60, 50}
+)
+
+// NewCallLogPDF starts a call log for the given period
+func NewCallLogPDF(startDate, endDate string) *CallLogPDF {
 	pdf := gofpdf.New("L", "mm", "A4", "") // Landscape orientation
 	pdf.AddPage()
 
@@ -145,44 +397,49 @@ func GenerateCallLogPDF(calls []db.Call, filename string, startDate, endDate str
 	pdf.Cell(277, 6, fmt.Sprintf("Period: %s to %s", startDate, endDate))
 	pdf.Ln(12)
 
-	// Table headers
-	pdf.SetFont("Arial", "B", 8)
-	headers := []string{"Date", "Time", "Address", "Town", "Type", "Disposition"}
-	widths := []float64{25, 15, 80, 40, 60, 50}
-	
-	for i, header := range headers {
-		pdf.Cell(widths[i], 8, header)
+	callLog := &CallLogPDF{pdf: pdf}
+	callLog.writeHeaders()
+	return callLog
+}
+
+// writeHeaders prints the table headers and switches to the row font
+func (l *CallLogPDF) writeHeaders() {
+	l.pdf.SetFont("Arial", "B", 8)
+	for i, header := range callLogHeaders {
+		l.pdf.Cell(callLogWidths[i], 8, header)
 	}
-	pdf.Ln(8)
+	l.pdf.Ln(8)
+	l.pdf.SetFont("Arial", "", 8)
+}
 
-	// Table rows
-	pdf.SetFont("Arial", "", 8)
+// AddCalls appends a row for each call
+func (l *CallLogPDF) AddCalls(calls []db.Call) {
+	pdf := l.pdf
 	for _, call := range calls {
-		pdf.Cell(widths[0], 6, call.CreatedAt.Format("01/02"))
-		pdf.Cell(widths[1], 6, call.CreatedAt.Format("15:04"))
-		pdf.Cell(widths[2], 6, call.Address)
-		pdf.Cell(widths[3], 6, call.Town)
-		pdf.Cell(widths[4], 6, call.CallType)
+		pdf.Cell(callLogWidths[0], 6, call.CreatedAt.Format("01/02"))
+		pdf.Cell(callLogWidths[1], 6, call.CreatedAt.Format("15:04"))
+		pdf.Cell(callLogWidths[2], 6, call.Address)
+		pdf.Cell(callLogWidths[3], 6, call.Town)
+		pdf.Cell(callLogWidths[4], 6, call.CallType)
 		pdf.Ln(6)
 		
 		// Check if we need a new page
 		if pdf.GetY() > 180 {
 			pdf.AddPage()
 			// Re-print headers
-			pdf.SetFont("Arial", "B", 8)
-			for i, header := range headers {
-				pdf.Cell(widths[i], 8, header)
-			}
-			pdf.Ln(8)
-			pdf.SetFont("Arial", "", 8)
+			l.writeHeaders()
 		}
 	}
+}
 
-	return pdf.OutputFileAndClose(filename)
+// Save writes the call log to filename
+func (l *CallLogPDF) Save(filename string) error {
+	return l.pdf.OutputFileAndClose(filename)
 }
 
-// GenerateSummaryPDF generates a summary statistics PDF
-func GenerateSummaryPDF(calls []db.Call, filename string, startDate, endDate string) error {
+// GenerateSummaryPDF generates a summary statistics PDF. Fire loss totals
+// are included when losses is not nil.
+func GenerateSummaryPDF(calls []db.Call, losses *db.FireLossTotals, filename string, startDate, endDate string) error {
 	pdf := gofpdf.New("P", "mm", "A4", "")
 	pdf.AddPage()
 
@@ -200,6 +457,30 @@ func GenerateSummaryPDF(calls []db.Call, filename string, startDate, endDate str
 	pdf.Cell(190, 8, fmt.Sprintf("Total Calls: %d", len(calls)))
 	pdf.Ln(12)
 
+	// Fire loss totals
+	if losses != nil {
+		pdf.SetFont("Arial", "B", 12)
+		pdf.Cell(190, 8, "Fire Losses")
+		pdf.Ln(10)
+
+		pdf.SetFont("Arial", "", 10)
+		pdf.Cell(100, 6, "Fires with loss reports:")
+		pdf.Cell(90, 6, fmt.Sprintf("%d", losses.Fires))
+		pdf.Ln(6)
+		pdf.Cell(100, 6, "Property loss:")
+		pdf.Cell(90, 6, formatDollars(losses.PropertyLoss))
+		pdf.Ln(6)
+		pdf.Cell(100, 6, "Contents loss:")
+		pdf.Cell(90, 6, formatDollars(losses.ContentsLoss))
+		pdf.Ln(6)
+		pdf.Cell(100, 6, "Total loss:")
+		pdf.Cell(90, 6, formatDollars(losses.TotalLoss()))
+		pdf.Ln(6)
+		pdf.Cell(100, 6, "Value saved:")
+		pdf.Cell(90, 6, formatDollars(losses.ValueSaved))
+		pdf.Ln(12)
+	}
+
 	// Statistics by call type
 	callTypes := make(map[string]int)
 	towns := make(map[string]int)
@@ -239,3 +520,73 @@ func GenerateSummaryPDF(calls []db.Call, filename string, startDate, endDate str
 
 	return pdf.OutputFileAndClose(filename)
 }
+
+// GenerateEventRosterPDF generates an attendance roster for a training,
+// drill, meeting or detail event
+func GenerateEventRosterPDF(event *db.Event, filename string) error {
+	pdf := gofpdf.New("P", "mm", "A4", "")
+	pdf.AddPage()
+
+	// Header
+	pdf.SetFont("Arial", "B", 16)
+	pdf.Cell(190, 10, "Attendance Roster")
+	pdf.Ln(15)
+
+	pdf.SetFont("Arial", "B", 12)
+	pdf.Cell(40, 8, "Event Information")
+	pdf.Ln(10)
+
+	pdf.SetFont("Arial", "", 10)
+	rows := []struct {
+		label string
+		value string
+	}{
+		{"Event #:", event.EventNumber},
+		{"Type:", event.EventType},
+		{"Topic:", event.Topic},
+		{"Instructor:", event.Instructor},
+		{"Location:", event.Location},
+		{"Date:", event.StartTime.Format("01/02/2006 15:04")},
+		{"Hours:", fmt.Sprintf("%g", event.Hours)},
+	}
+	for _, row := range rows {
+		pdf.Cell(50, 6, row.label)
+		pdf.Cell(140, 6, row.value)
+		pdf.Ln(6)
+	}
+
+	if event.Notes != "" {
+		pdf.Ln(4)
+		for _, line := range pdf.SplitText(event.Notes, 180) {
+			pdf.Cell(190, 6, line)
+			pdf.Ln(6)
+		}
+	}
+	pdf.Ln(4)
+
+	// Attendance table
+	pdf.SetFont("Arial", "B", 12)
+	pdf.Cell(40, 8, fmt.Sprintf("Attendance (%d)", len(event.Attendees)))
+	pdf.Ln(10)
+
+	headers := []string{"Name", "Position", "EMS Level", "Hours", "Signature"}
+	widths := []float64{55, 35, 30, 20, 50}
+
+	pdf.SetFont("Arial", "B", 10)
+	for i, header := range headers {
+		pdf.CellFormat(widths[i], 8, header, "1", 0, "L", false, 0, "")
+	}
+	pdf.Ln(8)
+
+	pdf.SetFont("Arial", "", 10)
+	for _, user := range event.Attendees {
+		pdf.CellFormat(widths[0], 8, user.LastName+", "+user.FirstName, "1", 0, "L", false, 0, "")
+		pdf.CellFormat(widths[1], 8, user.Position, "1", 0, "L", false, 0, "")
+		pdf.CellFormat(widths[2], 8, user.EMSLevel, "1", 0, "L", false, 0, "")
+		pdf.CellFormat(widths[3], 8, fmt.Sprintf("%g", event.Hours), "1", 0, "L", false, 0, "")
+		pdf.CellFormat(widths[4], 8, "", "1", 0, "L", false, 0, "")
+		pdf.Ln(8)
+	}
+
+	return pdf.OutputFileAndClose(filename)
+}